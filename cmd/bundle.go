@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+)
+
+// bundleConfig specifies the configuration of converting proof bundle directories to and from bundle files.
+type bundleConfig struct {
+	dir              string
+	file             string
+	verifyingKey     string
+	omitVerifyingKey bool
+}
+
+// newBundleCmd returns a new cobra.Command for managing single-file proof bundles.
+func newBundleCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "bundle",
+		Short: "Converts proof bundles to and from single files.",
+		Long: "Converts proof bundle directories written by prove to and from single versioned files holding the manifest, " +
+			"public inputs, proof and verifying key or its hash. Bundle files are verified with verify --bundle.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newBundlePackCmd returns a new cobra.Command for packing a proof bundle directory into a file.
+func newBundlePackCmd() *cobra.Command {
+	var conf bundleConfig
+
+	cmd := &cobra.Command{
+		Use:   "pack",
+		Short: "Packs a proof bundle directory into a bundle file.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return bundlePack(conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&conf.dir, "bundle-dir", "", "The path to the proof bundle directory written by prove, e.g. proofs/crop.")
+	cmd.Flags().StringVar(&conf.file, "bundle", "", "The path to the bundle file to write.")
+	cmd.Flags().StringVar(&conf.verifyingKey, "verifying-key", "", "The path to the verifying key generated by setup. Defaults to the verifying key in the bundle directory.")
+	cmd.Flags().BoolVar(&conf.omitVerifyingKey, "omit-verifying-key", false, "Only include the hash of the verifying key, verifiers pin the key with verify --verifying-key.")
+	_ = cmd.MarkFlagRequired("bundle-dir")
+	_ = cmd.MarkFlagRequired("bundle")
+
+	return cmd
+}
+
+// newBundleUnpackCmd returns a new cobra.Command for unpacking a bundle file into a proof bundle directory.
+func newBundleUnpackCmd() *cobra.Command {
+	var conf bundleConfig
+
+	cmd := &cobra.Command{
+		Use:   "unpack",
+		Short: "Unpacks a bundle file into a proof bundle directory.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return bundleUnpack(conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&conf.file, "bundle", "", "The path to the bundle file written by bundle pack.")
+	cmd.Flags().StringVar(&conf.dir, "bundle-dir", "", "The path to the proof bundle directory to write.")
+	_ = cmd.MarkFlagRequired("bundle")
+	_ = cmd.MarkFlagRequired("bundle-dir")
+
+	return cmd
+}
+
+// newBundleInspectCmd returns a new cobra.Command for printing the contents of a bundle file.
+func newBundleInspectCmd() *cobra.Command {
+	var conf bundleConfig
+
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Prints the manifest and contents of a bundle file.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return bundleInspect(conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&conf.file, "bundle", "", "The path to the bundle file written by bundle pack.")
+	_ = cmd.MarkFlagRequired("bundle")
+
+	return cmd
+}
+
+// bundlePack writes the bundle file of the proof bundle directory.
+func bundlePack(config bundleConfig) error {
+	manifest, proof, vk, err := readBundleDir(config.dir, config.verifyingKey)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := maya.NewBundle(manifest, proof, vk, !config.omitVerifyingKey)
+	if err != nil {
+		return err
+	}
+
+	b, err := bundle.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(config.file, b, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Bundle size: ", len(b))
+	fmt.Println("Bundle written to", config.file)
+
+	return nil
+}
+
+// bundleUnpack writes the proof bundle directory of the bundle file.
+func bundleUnpack(config bundleConfig) error {
+	bundle, err := readBundleFile(config.file)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(config.dir, 0o777); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(path.Join(config.dir, "proof.bin"), bundle.Proof, 0o644); err != nil {
+		return err
+	}
+
+	if bundle.VerifyingKey != nil {
+		if err = os.WriteFile(path.Join(config.dir, "vkey.bin"), bundle.VerifyingKey, 0o644); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("Bundle does not include the verifying key, its hash is", bundle.Manifest.VerifyingKeyHash)
+	}
+
+	if err = writeManifest(config.dir, &bundle.Manifest); err != nil {
+		return err
+	}
+
+	fmt.Println("Proof bundle written to", config.dir)
+
+	return nil
+}
+
+// bundleInspect prints the manifest and contents of the bundle file.
+func bundleInspect(config bundleConfig) error {
+	bundle, err := readBundleFile(config.file)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := json.MarshalIndent(bundle.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Bundle version: ", bundle.Version)
+	fmt.Println("Proof size: ", len(bundle.Proof))
+	if bundle.VerifyingKey != nil {
+		fmt.Println("Verifying key size: ", len(bundle.VerifyingKey))
+	} else {
+		fmt.Println("Verifying key not included, pin it with verify --verifying-key")
+	}
+	fmt.Println("Manifest:")
+	fmt.Println(string(manifest))
+
+	return nil
+}
+
+// loadBundle returns the bundle at the path, either a proof bundle directory or a bundle file.
+// The verifying key of a directory defaults to the one in the directory, if any.
+func loadBundle(bundlePath, verifyingKey string) (*maya.Bundle, error) {
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return readBundleFile(bundlePath)
+	}
+
+	manifest, proof, vk, err := readBundleDir(bundlePath, verifyingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return maya.NewBundle(manifest, proof, vk, vk != nil)
+}
+
+// readBundleFile returns the bundle of the bundle file.
+func readBundleFile(file string) (*maya.Bundle, error) {
+	b, err := readFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return maya.UnmarshalBundle(b)
+}
+
+// readBundleDir returns the manifest, proof and verifying key of the proof bundle directory. The verifying key
+// defaults to the one in the directory, and is nil if the directory does not include it.
+func readBundleDir(dir, verifyingKey string) (maya.Manifest, []byte, []byte, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return maya.Manifest{}, nil, nil, err
+	}
+
+	proof, err := readFromFile(path.Join(dir, "proof.bin"))
+	if err != nil {
+		return maya.Manifest{}, nil, nil, err
+	}
+
+	vkPath := verifyingKey
+	if vkPath == "" {
+		vkPath = path.Join(dir, "vkey.bin")
+	}
+
+	vk, err := readFromFile(vkPath)
+	if errors.Is(err, os.ErrNotExist) && verifyingKey == "" {
+		return manifest, proof, nil, nil
+	} else if err != nil {
+		return maya.Manifest{}, nil, nil, err
+	}
+
+	return manifest, proof, vk, nil
+}
+
+// readEmbeddedBundle returns the bundle embedded in the PNG image.
+func readEmbeddedBundle(file string) (*maya.Bundle, error) {
+	b, err := readFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return maya.ExtractBundle(b)
+}
+
+// checkPNG returns an error if the image file is not a PNG, so a bundle can be embedded into it.
+func checkPNG(file string) error {
+	b, err := readFromFile(file)
+	if err != nil {
+		return err
+	}
+
+	if err = maya.CheckPNG(b); err != nil {
+		return fmt.Errorf("embed bundle into %s, %w", file, err)
+	}
+
+	return nil
+}
+
+// embedBundle embeds the bundle into the PNG image, replacing the file.
+func embedBundle(file string, bundle *maya.Bundle) error {
+	b, err := readFromFile(file)
+	if err != nil {
+		return err
+	}
+
+	b, err = maya.EmbedBundle(b, bundle)
+	if err != nil {
+		return fmt.Errorf("embed bundle into %s, %w", file, err)
+	}
+
+	// Write to a temporary file first, so the image is not corrupted if writing fails.
+	tmp := file + ".tmp"
+	if err = os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// readManifest returns the manifest of the proof bundle directory.
+func readManifest(dir string) (maya.Manifest, error) {
+	b, err := readFromFile(path.Join(dir, manifestFile))
+	if err != nil {
+		return maya.Manifest{}, err
+	}
+
+	var manifest maya.Manifest
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		return maya.Manifest{}, fmt.Errorf("invalid manifest, %w", err)
+	}
+
+	return manifest, nil
+}
+
+// writeManifest writes the manifest to the proof bundle directory.
+func writeManifest(dir string, manifest *maya.Manifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(dir, manifestFile), b, 0o644)
+}
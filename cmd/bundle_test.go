@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	err = prove(ctx, crop, proveConfig{
+		originalImg: "../sample/original.png",
+		finalImg:    "../sample/cropped2.png",
+		proofDir:    dir,
+		backend:     transform.BackendGroth16,
+		params:      transform.Params{"width-start-new": "2", "height-start-new": "2"},
+	})
+	require.NoError(t, err)
+
+	bundleDir := path.Join(dir, "crop")
+	file := path.Join(dir, "crop.maya")
+
+	err = bundlePack(bundleConfig{dir: bundleDir, file: file})
+	require.NoError(t, err)
+	require.NoError(t, bundleInspect(bundleConfig{file: file}))
+
+	conf := verifyConfig{
+		bundle:       file,
+		finalImg:     "../sample/cropped2.png",
+		originalHash: imageHash(t, "../sample/original.png"),
+	}
+
+	err = verifyBundle(ctx, conf)
+	require.NoError(t, err)
+
+	// Unpacking restores the proof bundle directory.
+	unpacked := path.Join(dir, "unpacked")
+	err = bundleUnpack(bundleConfig{file: file, dir: unpacked})
+	require.NoError(t, err)
+
+	for _, name := range []string{manifestFile, "proof.bin", "vkey.bin"} {
+		want, err := os.ReadFile(path.Join(bundleDir, name))
+		require.NoError(t, err)
+
+		got, err := os.ReadFile(path.Join(unpacked, name))
+		require.NoError(t, err)
+		require.Equal(t, want, got, name)
+	}
+
+	// Bundles without the verifying key require verifiers to pin it.
+	err = bundlePack(bundleConfig{dir: bundleDir, file: file, omitVerifyingKey: true})
+	require.NoError(t, err)
+
+	err = verifyBundle(ctx, conf)
+	require.ErrorContains(t, err, "does not include the verifying key")
+
+	conf.verifyingKey = path.Join(bundleDir, "vkey.bin")
+	err = verifyBundle(ctx, conf)
+	require.NoError(t, err)
+
+	err = bundleUnpack(bundleConfig{file: file, dir: path.Join(dir, "unpacked-hash")})
+	require.NoError(t, err)
+
+	_, err = os.Stat(path.Join(dir, "unpacked-hash", "vkey.bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	conf.bundle = path.Join(bundleDir, "proof.bin")
+	err = verifyBundle(ctx, conf)
+	require.ErrorContains(t, err, "invalid bundle")
+}
+
+func TestEmbedBundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	b, err := os.ReadFile("../sample/cropped2.png")
+	require.NoError(t, err)
+
+	finalImg := path.Join(dir, "cropped2.png")
+	require.NoError(t, os.WriteFile(finalImg, b, 0o644))
+
+	conf := proveConfig{
+		originalImg: "../sample/original.png",
+		finalImg:    finalImg,
+		proofDir:    dir,
+		backend:     transform.BackendGroth16,
+		embed:       true,
+		params:      transform.Params{"width-start-new": "2", "height-start-new": "2"},
+	}
+
+	err = prove(ctx, crop, proveConfig{provingKey: "pkey.bin", embed: true})
+	require.ErrorContains(t, err, "requires its verifying key")
+
+	// The final image is checked before proving.
+	notPNG := path.Join(dir, "cropped2.txt")
+	require.NoError(t, os.WriteFile(notPNG, []byte("not a png"), 0o644))
+
+	err = prove(ctx, crop, proveConfig{finalImg: notPNG, embed: true})
+	require.ErrorContains(t, err, "not a PNG image")
+
+	err = prove(ctx, crop, conf)
+	require.NoError(t, err)
+
+	// The proof bundle and the verifying key of the insecure setup are read from the final image.
+	verifyConf := verifyConfig{
+		finalImg:     finalImg,
+		originalHash: imageHash(t, "../sample/original.png"),
+	}
+
+	err = verifyBundle(ctx, verifyConf)
+	require.NoError(t, err)
+
+	verifyConf.verifyingKey = path.Join(dir, "crop", "vkey.bin")
+	err = verifyBundle(ctx, verifyConf)
+	require.NoError(t, err)
+
+	verifyConf.finalImg = "../sample/cropped2.png"
+	err = verifyBundle(ctx, verifyConf)
+	require.ErrorContains(t, err, "does not include an embedded bundle")
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+)
+
+const (
+	ceremonyFile = "ceremony.json"
+	phase1File   = "phase1.bin"
+)
+
+// ceremonyConfig specifies the configuration of a groth16 setup ceremony stored in a local directory.
+type ceremonyConfig struct {
+	width       int
+	height      int
+	finalWidth  int
+	finalHeight int
+	alpha       bool
+	bitDepth    int
+	signed      bool
+	phase1      string
+	dir         string
+	name        string
+	keyDir      string
+	params      transform.Params
+}
+
+// newCeremonyCmd returns a new cobra.Command for running multi-party groth16 setup ceremonies.
+func newCeremonyCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "ceremony",
+		Short: "Runs a multi-party groth16 setup ceremony for the specified transformation.",
+		Long: "Runs a multi-party groth16 phase 2 setup ceremony of a transformation circuit for fixed image dimensions. " +
+			"The resulting keys are secure if any participant discarded their contribution.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newCeremonyInitCmd returns a new cobra.Command for initialising the ceremonies of transformation circuits.
+func newCeremonyInitCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "init",
+		Short: "Initialises the ceremony of the specified transformation.",
+		Long:  "Initialises the ceremony directory with the phase 1 parameters and the initial phase 2 parameters of the transformation circuit.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newCeremonyInitTransformationCmd returns a new cobra.Command for initialising the ceremony of the transformation circuit.
+func newCeremonyInitTransformationCmd(t transform.Transformation) *cobra.Command {
+	conf := ceremonyConfig{params: make(transform.Params)}
+
+	cmd := &cobra.Command{
+		Use:   t.Name(),
+		Short: t.Description(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ceremonyInit(cmd.Context(), t, conf)
+		},
+	}
+
+	cmd.Flags().IntVar(&conf.width, "width", 0, "The width of the original image.")
+	cmd.Flags().IntVar(&conf.height, "height", 0, "The height of the original image.")
+	cmd.Flags().IntVar(&conf.finalWidth, "final-width", 0, "The width of the final image. Derived from the original image and parameters if zero.")
+	cmd.Flags().IntVar(&conf.finalHeight, "final-height", 0, "The height of the final image. Derived from the original image and parameters if zero.")
+	cmd.Flags().BoolVar(&conf.alpha, "alpha", false, "Whether the original image has an alpha channel, see prove.")
+	bindBitDepthFlag(cmd, &conf.bitDepth)
+	cmd.Flags().BoolVar(&conf.signed, "signed", false, "Generate keys for proofs of signed original images, see prove --signer-public-key.")
+	cmd.Flags().StringVar(&conf.phase1, "phase1", "", "The path to the phase 1 powers of tau, in snarkjs .ptau or gnark format.")
+	bindCeremonyDirFlag(cmd, &conf)
+	_ = cmd.MarkFlagRequired("width")
+	_ = cmd.MarkFlagRequired("height")
+	_ = cmd.MarkFlagRequired("phase1")
+	bindParamFlags(cmd, t, conf.params)
+
+	return cmd
+}
+
+// newCeremonyContributeCmd returns a new cobra.Command for contributing to a ceremony.
+func newCeremonyContributeCmd() *cobra.Command {
+	var conf ceremonyConfig
+
+	cmd := &cobra.Command{
+		Use:   "contribute",
+		Short: "Contributes randomness to the ceremony.",
+		Long:  "Verifies the ceremony and adds a random contribution on top of the latest one. The contribution is discarded after use.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ceremonyContribute(cmd.Context(), conf)
+		},
+	}
+
+	bindCeremonyDirFlag(cmd, &conf)
+	cmd.Flags().StringVar(&conf.name, "name", "", "The name of the participant recorded in the transcript.")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// newCeremonyVerifyCmd returns a new cobra.Command for verifying a ceremony.
+func newCeremonyVerifyCmd() *cobra.Command {
+	var conf ceremonyConfig
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the ceremony transcript and every contribution.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ceremonyVerify(cmd.Context(), conf)
+		},
+	}
+
+	bindCeremonyDirFlag(cmd, &conf)
+
+	return cmd
+}
+
+// newCeremonyFinalizeCmd returns a new cobra.Command for generating the keys of a ceremony.
+func newCeremonyFinalizeCmd() *cobra.Command {
+	var conf ceremonyConfig
+
+	cmd := &cobra.Command{
+		Use:   "finalize",
+		Short: "Verifies the ceremony and writes the proving and verifying keys.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ceremonyFinalize(cmd.Context(), conf)
+		},
+	}
+
+	bindCeremonyDirFlag(cmd, &conf)
+	cmd.Flags().StringVar(&conf.keyDir, "key-dir", "", "The path to the directory to write the proving and verifying keys to.")
+
+	return cmd
+}
+
+func bindCeremonyDirFlag(cmd *cobra.Command, conf *ceremonyConfig) {
+	cmd.Flags().StringVar(&conf.dir, "ceremony-dir", "ceremony", "The path to the directory of the ceremony transcript and contributions.")
+}
+
+// ceremonyInit initialises the ceremony directory of the transformation circuit.
+func ceremonyInit(ctx context.Context, t transform.Transformation, config ceremonyConfig) error {
+	if _, err := os.Stat(path.Join(config.dir, ceremonyFile)); err == nil {
+		return fmt.Errorf("ceremony already exists in %s", config.dir)
+	}
+
+	file, err := os.Open(config.phase1)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	depth16, err := bitDepth16(config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	original := transform.Shape{Width: config.width, Height: config.height, Alpha: config.alpha, Depth16: depth16}
+	final := transform.Shape{Width: config.finalWidth, Height: config.finalHeight}
+
+	c, phase1, phase2, err := maya.InitCeremony(ctx, t.Name(), original, final, config.params, config.signed, file)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(config.dir, 0o777); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(path.Join(config.dir, phase1File), phase1, 0o644); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(path.Join(config.dir, phase2File(0)), phase2, 0o644); err != nil {
+		return err
+	}
+
+	if err = writeCeremony(config.dir, c); err != nil {
+		return err
+	}
+
+	fmt.Printf("Ceremony for %s to %s images initialised in %s\n", c.Original, c.Final, config.dir)
+	fmt.Println("Phase 1 hash:", c.Phase1Hash)
+	fmt.Println("Initial phase 2 hash:", c.Contributions[0].Hash)
+
+	return nil
+}
+
+// ceremonyContribute verifies the ceremony and adds a contribution to it.
+func ceremonyContribute(ctx context.Context, config ceremonyConfig) error {
+	c, phase1, phase2, err := readCeremony(config.dir)
+	if err != nil {
+		return err
+	}
+
+	if err = c.Verify(ctx, phase1, phase2); err != nil {
+		return err
+	}
+
+	next, err := c.Contribute(ctx, phase2[len(phase2)-1], config.name)
+	if err != nil {
+		return err
+	}
+
+	index := len(c.Contributions) - 1
+	if err = os.WriteFile(path.Join(config.dir, phase2File(index)), next, 0o644); err != nil {
+		return err
+	}
+
+	if err = writeCeremony(config.dir, c); err != nil {
+		return err
+	}
+
+	fmt.Printf("Contribution %d hash: %s\n", index, c.Contributions[index].Hash)
+	fmt.Println("Publish the hash so others can check the contribution is included in the transcript.")
+
+	return nil
+}
+
+// ceremonyVerify verifies the ceremony transcript and contributions.
+func ceremonyVerify(ctx context.Context, config ceremonyConfig) error {
+	c, phase1, phase2, err := readCeremony(config.dir)
+	if err != nil {
+		return err
+	}
+
+	if err = c.Verify(ctx, phase1, phase2); err != nil {
+		fmt.Println("Invalid ceremony 😞")
+		return err
+	}
+
+	fmt.Printf("Ceremony for %s with %s to %s images\n", c.Transformation, c.Original, c.Final)
+	fmt.Println("Phase 1 hash:", c.Phase1Hash)
+	for i, contribution := range c.Contributions {
+		fmt.Printf("Contribution %d by %s: %s\n", i, contribution.Name, contribution.Hash)
+	}
+	fmt.Println("Ceremony verified 🎉")
+
+	return nil
+}
+
+// ceremonyFinalize verifies the ceremony and writes the proving and verifying keys.
+func ceremonyFinalize(ctx context.Context, config ceremonyConfig) error {
+	c, phase1, phase2, err := readCeremony(config.dir)
+	if err != nil {
+		return err
+	}
+
+	keys, err := c.Finalize(ctx, phase1, phase2)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s ceremony with %d contributions finalised\n", c.Transformation, len(c.Contributions)-1)
+
+	return writeKeys(keys, config.keyDir)
+}
+
+// readCeremony returns the transcript, phase 1 and phase 2 parameters of the ceremony directory.
+func readCeremony(dir string) (*maya.Ceremony, []byte, [][]byte, error) {
+	b, err := os.ReadFile(path.Join(dir, ceremonyFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil, fmt.Errorf("no ceremony in %s, see ceremony init", dir)
+	} else if err != nil {
+		return nil, nil, nil, err
+	}
+
+	c := new(maya.Ceremony)
+	if err = json.Unmarshal(b, c); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid ceremony transcript, %w", err)
+	}
+
+	phase1, err := os.ReadFile(path.Join(dir, phase1File))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var phase2 [][]byte
+	for i := range c.Contributions {
+		b, err := os.ReadFile(path.Join(dir, phase2File(i)))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		phase2 = append(phase2, b)
+	}
+
+	return c, phase1, phase2, nil
+}
+
+// writeCeremony writes the transcript to the ceremony directory.
+func writeCeremony(dir string, c *maya.Ceremony) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(dir, ceremonyFile), b, 0o644)
+}
+
+// phase2File returns the file name of the phase 2 parameters of the contribution, 0 is the initial parameters.
+func phase2File(index int) string {
+	return fmt.Sprintf("phase2-%04d.bin", index)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestCeremony(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ceremonyDir := path.Join(dir, "ceremony")
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	// Small images keep the ceremony fast.
+	original := cropPixels(t, "../sample/original.png", 3)
+	final := transform.Pixels{Values: [][][]uint16{
+		{original.Values[1][1], original.Values[1][2]},
+		{original.Values[2][1], original.Values[2][2]},
+	}}
+	originalImg := writeImage(t, path.Join(dir, "original.png"), original)
+	finalImg := writeImage(t, path.Join(dir, "final.png"), final)
+
+	params := transform.Params{"width-start-new": "1", "height-start-new": "1"}
+
+	err = ceremonyInit(ctx, crop, ceremonyConfig{
+		width:  3,
+		height: 3,
+		phase1: writePhase1(t, dir, 10),
+		dir:    ceremonyDir,
+		params: params,
+	})
+	require.NoError(t, err)
+
+	// The ceremony requires at least one contribution.
+	err = ceremonyFinalize(ctx, ceremonyConfig{dir: ceremonyDir, keyDir: dir})
+	require.ErrorContains(t, err, "no contributions")
+
+	for _, name := range []string{"alice", "bob"} {
+		err = ceremonyContribute(ctx, ceremonyConfig{dir: ceremonyDir, name: name})
+		require.NoError(t, err)
+	}
+
+	err = ceremonyVerify(ctx, ceremonyConfig{dir: ceremonyDir})
+	require.NoError(t, err)
+
+	err = ceremonyFinalize(ctx, ceremonyConfig{dir: ceremonyDir, keyDir: dir})
+	require.NoError(t, err)
+
+	proofDir := path.Join(dir, "proofs")
+	err = prove(ctx, crop, proveConfig{
+		originalImg: originalImg,
+		finalImg:    finalImg,
+		proofDir:    proofDir,
+		backend:     transform.BackendGroth16,
+		provingKey:  path.Join(dir, "crop", "pkey.bin"),
+		params:      params,
+	})
+	require.NoError(t, err)
+
+	err = verify(ctx, crop, verifyConfig{
+		finalImg:     finalImg,
+		proofDir:     proofDir,
+		originalHash: imageHash(t, originalImg),
+		backend:      transform.BackendGroth16,
+		verifyingKey: path.Join(dir, "crop", "vkey.bin"),
+		params:       transform.Params{"width-start-new": "1", "height-start-new": "1"},
+	})
+	require.NoError(t, err)
+
+	// A contribution that is not in the transcript fails verification.
+	b, err := os.ReadFile(path.Join(ceremonyDir, ceremonyFile))
+	require.NoError(t, err)
+
+	var transcript map[string]any
+	require.NoError(t, json.Unmarshal(b, &transcript))
+	transcript["contributions"].([]any)[1].(map[string]any)["hash"] = "00"
+
+	b, err = json.Marshal(transcript)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(ceremonyDir, ceremonyFile), b, 0o644))
+
+	err = ceremonyVerify(ctx, ceremonyConfig{dir: ceremonyDir})
+	require.ErrorContains(t, err, "contribution 1 does not match the transcript")
+
+	err = ceremonyInit(ctx, crop, ceremonyConfig{width: 3, height: 3, dir: ceremonyDir, params: params})
+	require.ErrorContains(t, err, "ceremony already exists")
+}
+
+// writePhase1 writes the phase 1 parameters of the provided power with a single contribution and returns its path.
+func writePhase1(t *testing.T, dir string, power int) string {
+	t.Helper()
+
+	phase1 := mpcsetup.InitPhase1(power)
+	phase1.Contribute()
+
+	file, err := os.Create(path.Join(dir, "phase1.bin"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = phase1.WriteTo(file)
+	require.NoError(t, err)
+
+	return file.Name()
+}
@@ -1,53 +1,60 @@
 package cmd
 
 import (
-	"bytes"
-	"errors"
 	"fmt"
-	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/plonk"
-	"github.com/consensys/gnark/backend/witness"
-	"github.com/consensys/gnark/constraint"
-	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
-	"github.com/consensys/gnark/frontend/cs/scs"
-	"github.com/consensys/gnark/test"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark/logger"
 	"github.com/spf13/cobra"
 	"image"
 	"io"
 	"os"
+	"strings"
 )
 
+func init() {
+	// gnark logs to stdout by default, which would interleave with the command output.
+	logger.Disable()
+}
+
 // New returns a new cobra command that handles maya cli commands and subcommands.
 func New() *cobra.Command {
+	var setupCmds, ceremonyCmds, proveCmds, verifyCmds []*cobra.Command
+	for _, t := range transform.All() {
+		setupCmds = append(setupCmds, newSetupTransformationCmd(t))
+		ceremonyCmds = append(ceremonyCmds, newCeremonyInitTransformationCmd(t))
+		proveCmds = append(proveCmds, newProveTransformationCmd(t))
+		verifyCmds = append(verifyCmds, newVerifyTransformationCmd(t))
+	}
+
 	return newRootCmd(
-		newProveCmd(
-			newCropCmd(),
-			newRotate90Cmd(),
-			newRotate180Cmd(),
-			newRotate270Cmd(),
-			newFlipVerticalCmd(),
-			newFlipHorizontalCmd(),
-			newBrightenCmd(),
+		newSetupCmd(setupCmds...),
+		newCeremonyCmd(
+			newCeremonyInitCmd(ceremonyCmds...),
+			newCeremonyContributeCmd(),
+			newCeremonyVerifyCmd(),
+			newCeremonyFinalizeCmd(),
 		),
-		newVerifyCmd(
-			newVerifyCropCmd(),
-			newVerifyRotate90Cmd(),
-			newVerifyRotate180Cmd(),
-			newVerifyRotate270Cmd(),
-			newVerifyFlipVerticalCmd(),
-			newVerifyFlipHorizontalCmd(),
-			newVerifyBrightenCmd(),
+		newProveCmd(proveCmds...),
+		newKeysCmd(
+			newKeysGenerateCmd(),
+			newKeysSignImageCmd(),
+		),
+		newVerifyCmd(verifyCmds...),
+		newBundleCmd(
+			newBundlePackCmd(),
+			newBundleUnpackCmd(),
+			newBundleInspectCmd(),
 		),
 	)
 }
 
 func newRootCmd(cmds ...*cobra.Command) *cobra.Command {
 	root := &cobra.Command{
-		Use:   "maya",
-		Short: "Maya CLI",
-		Long:  "Command line tool to create zero-knowledge proof of image transformations.",
+		Use:     "maya",
+		Short:   "Maya CLI",
+		Long:    "Command line tool to create zero-knowledge proof of image transformations.",
+		Version: maya.Version,
 	}
 
 	root.AddCommand(cmds...)
@@ -67,149 +74,66 @@ func newProveCmd(cmds ...*cobra.Command) *cobra.Command {
 	return root
 }
 
-func newVerifyCmd(cmds ...*cobra.Command) *cobra.Command {
-	root := &cobra.Command{
-		Use:   "verify",
-		Short: "Verifies proof for the specified transformation.",
-		Long:  "Verifies the zero knowledge proof of transformation on the original image resulting in a new image.",
-	}
+// imageFormatsUsage is the usage suffix of image path flags.
+const imageFormatsUsage = "Supported image formats: PNG, JPEG, GIF, BMP, TIFF and WebP."
 
-	root.AddCommand(cmds...)
-
-	return root
+// bindFormatFlag binds the flag overriding the detected format of the images.
+func bindFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVar(format, "format", "", "The format of the images, detected from their content by default. Supported: "+
+		strings.Join(maya.Formats, ", ")+".")
 }
 
-func compileCircuit(backend string, circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
-	switch backend {
-	case "groth16":
-		return frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
-	case "plonk": // TODO(dhruv): add plonkfri when its serialisation is supported.
-		return frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
-	default:
-		return nil, errors.New(fmt.Sprintf("invalid backend, %s", backend))
-	}
+// bindBitDepthFlag binds the flag overriding the bit depth of the images, which is detected from the images by default.
+// Commands without images, e.g. setup, default to 8-bit images, see bitDepth16.
+func bindBitDepthFlag(cmd *cobra.Command, bitDepth *int) {
+	cmd.Flags().IntVar(bitDepth, "bit-depth", 0, "The bit depth of the image channel values, 8 or 16. Detected from the images by default, "+
+		"e.g. 16 for 16-bit PNG and TIFF images, or 8 for commands without images, e.g. setup.")
 }
 
-func generateProofByBackend(backend string, cs constraint.ConstraintSystem, witness witness.Witness) (io.WriterTo, io.WriterTo, error) {
-	switch backend {
-	case "groth16":
-		pk, vk, err := groth16.Setup(cs)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		proof, err := groth16.Prove(cs, pk, witness)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		return proof, vk, nil
-	case "plonk":
-		// TODO(dhruv): replace this with actual trusted setup ceremony.
-		kzgSrs, err := test.NewKZGSRS(cs)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		pk, vk, err := plonk.Setup(cs, kzgSrs)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		proof, err := plonk.Prove(cs, pk, witness)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		return proof, vk, nil
-	default:
-		return nil, nil, errors.New(fmt.Sprintf("invalid backend, %s", backend))
+// bitDepth16 returns true if the bit depth of the original image channel values is 16, or an error if it is not 8 or 16.
+// Zero is the default of commands without images to detect it from, see bindBitDepthFlag, which is 8.
+func bitDepth16(bitDepth int) (bool, error) {
+	if bitDepth != 0 && bitDepth != 8 && bitDepth != 16 {
+		return false, fmt.Errorf("unsupported bit depth %d, supported: 8 and 16", bitDepth)
 	}
-}
 
-// VerifyProofByBackend verifies the given proof by provided proof system backend.
-func VerifyProofByBackend(backend, transformation string, proof, vk []byte, finalImg image.Image) error {
-	pubWit, err := publicWitness(transformation, finalImg)
-	if err != nil {
-		return err
-	}
-
-	switch backend {
-	case "groth16":
-		grothProof := groth16.NewProof(ecc.BN254)
-		_, err := grothProof.ReadFrom(bytes.NewBuffer(proof))
-		if err != nil {
-			return err
-		}
-
-		grothVk := groth16.NewVerifyingKey(ecc.BN254)
-		_, err = grothVk.ReadFrom(bytes.NewBuffer(vk))
-		if err != nil {
-			return err
-		}
-
-		return groth16.Verify(grothProof, grothVk, pubWit)
-	case "plonk":
-		plonkProof := plonk.NewProof(ecc.BN254)
-		_, err := plonkProof.ReadFrom(bytes.NewBuffer(proof))
-		if err != nil {
-			return err
-		}
-
-		plonkVk := plonk.NewVerifyingKey(ecc.BN254)
-		_, err = plonkVk.ReadFrom(bytes.NewBuffer(vk))
-		if err != nil {
-			return err
-		}
-
-		return plonk.Verify(plonkProof, plonkVk, pubWit)
-	default:
-		return errors.New(fmt.Sprintf("invalid backend, %s", backend))
-	}
+	return bitDepth == 16, nil
 }
 
-// publicWitness returns public witness for the given transformation.
-func publicWitness(transformation string, finalImg image.Image) (witness.Witness, error) {
-	pixels, err := convertImgToPixels(finalImg)
+// loadImage returns the image at the provided path, decoded in the format, see maya.DecodeImageFormat, and converted
+// to the bit depth, see maya.ConvertBitDepth.
+func loadImage(path, format string, bitDepth int) (image.Image, error) {
+	imgFile, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer imgFile.Close()
 
-	switch transformation {
-	case "crop":
-		wt, err := frontend.NewWitness(&CropCircuit{
-			Cropped: convertToFrontendVariable(pixels),
-		}, ecc.BN254.ScalarField())
-		if err != nil {
-			return nil, err
-		}
-
-		return wt.Public()
-	case "flip_horizontal":
-		wt, err := frontend.NewWitness(&FlipHorizontalCircuit{
-			Flipped: convertToFrontendVariable(pixels),
-		}, ecc.BN254.ScalarField())
-		if err != nil {
-			return nil, err
-		}
-
-		return wt.Public()
-	default:
-		return nil, errors.New("invalid transformation")
+	img, err := maya.DecodeImageFormat(imgFile, format)
+	if err != nil {
+		return nil, fmt.Errorf("load image %s, %w", path, err)
 	}
+
+	return maya.ConvertBitDepth(img, bitDepth)
 }
 
-func loadImage(path string) (image.Image, error) {
-	imgFile, err := os.Open(path)
+// loadPixels returns the pixel values of the image at the provided path, decoded in the format and converted to the
+// bit depth.
+func loadPixels(path, format string, bitDepth int) (transform.Pixels, error) {
+	img, err := loadImage(path, format, bitDepth)
 	if err != nil {
-		return nil, err
+		return transform.Pixels{}, err
 	}
-	defer imgFile.Close()
 
-	img, _, err := image.Decode(imgFile)
+	return transform.FromImage(img), nil
+}
+
+func readFromFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	return img, nil
+	return io.ReadAll(file)
 }
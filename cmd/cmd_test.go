@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+)
+
+// transparentWebP is a lossless 1x1 WebP image, as there is no WebP encoder.
+const transparentWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+func TestImageFormats(t *testing.T) {
+	original, err := loadPixels("../sample/original.png", "", 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		format string
+		encode func(io.Writer, image.Image) error
+		// lossy formats change the pixels, so the final image is cropped from the decoded original image and is a PNG.
+		lossy bool
+	}{
+		{format: "png", encode: png.Encode},
+		{format: "jpeg", encode: func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) }, lossy: true},
+		{format: "gif", encode: func(w io.Writer, img image.Image) error { return gif.Encode(w, img, nil) }, lossy: true},
+		{format: "bmp", encode: bmp.Encode},
+		{format: "tiff", encode: func(w io.Writer, img image.Image) error { return tiff.Encode(w, img, nil) }},
+		{format: "webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := t.TempDir()
+			originalImg := path.Join(dir, "original."+tt.format)
+
+			if tt.encode == nil {
+				b, err := base64.StdEncoding.DecodeString(transparentWebP)
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(originalImg, b, 0o644))
+			} else {
+				writeEncodedImage(t, originalImg, original.Image(), tt.encode)
+			}
+
+			decoded, err := loadPixels(originalImg, "", 0)
+			require.NoError(t, err)
+
+			// Crop the center of the original image.
+			shape := decoded.Shape()
+			offset := strconv.Itoa(shape.Width / 4)
+			params := transform.Params{"width-start-new": offset, "height-start-new": offset, "width-new": strconv.Itoa((shape.Width + 1) / 2), "height-new": strconv.Itoa((shape.Height + 1) / 2)}
+
+			crop, err := transform.Get("crop")
+			require.NoError(t, err)
+
+			final, err := crop.Apply(decoded, params.WithDefaults(crop))
+			require.NoError(t, err)
+
+			// The format is detected from the content, or provided if the images have the same format.
+			var format []string
+			finalImg := path.Join(dir, "final.png")
+			if tt.lossy || tt.encode == nil {
+				writeImage(t, finalImg, final)
+			} else {
+				finalImg = path.Join(dir, "final."+tt.format)
+				writeEncodedImage(t, finalImg, final.Image(), tt.encode)
+				format = []string{"--format=" + tt.format}
+			}
+
+			err = execute(append([]string{
+				"prove", "crop",
+				"--original-image=" + originalImg,
+				"--final-image=" + finalImg,
+				"--proof-dir=" + dir,
+				"--width-start-new=" + params["width-start-new"],
+				"--height-start-new=" + params["height-start-new"],
+				"--width-new=" + params["width-new"],
+				"--height-new=" + params["height-new"],
+			}, format...)...)
+			require.NoError(t, err)
+
+			err = execute(append([]string{
+				"verify", "crop",
+				"--final-image=" + finalImg,
+				"--proof-dir=" + dir,
+				"--original-hash=" + imageHash(t, originalImg),
+				"--width-start-new=" + params["width-start-new"],
+				"--height-start-new=" + params["height-start-new"],
+				"--original-width=" + strconv.Itoa(shape.Width),
+				"--original-height=" + strconv.Itoa(shape.Height),
+			}, format...)...)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestImageFormatErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	text := path.Join(dir, "original.png")
+	require.NoError(t, os.WriteFile(text, []byte("not an image"), 0o644))
+
+	tests := []struct {
+		name     string
+		original string
+		format   string
+		err      string
+	}{
+		{name: "unsupported", original: "../sample/original.png", format: "heic", err: `unsupported image format "heic"`},
+		{name: "mismatch", original: "../sample/original.png", format: "jpeg", err: "decode jpeg image"},
+		{name: "unknown", original: text, err: "unknown image format, supported: png, jpeg, gif, bmp, tiff, webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := execute(
+				"prove", "rotate90",
+				"--original-image="+tt.original,
+				"--final-image=../sample/rotated90.png",
+				"--proof-dir="+dir,
+				"--format="+tt.format,
+			)
+			require.ErrorContains(t, err, tt.err)
+			require.ErrorContains(t, err, tt.original)
+		})
+	}
+}
+
+func TestReencode(t *testing.T) {
+	dir := t.TempDir()
+
+	original, err := loadPixels("../sample/original.png", "", 0)
+	require.NoError(t, err)
+
+	// Re-encodings in other lossless formats have the same pixels.
+	tiffImg := path.Join(dir, "final.tiff")
+	writeEncodedImage(t, tiffImg, original.Image(), func(w io.Writer, img image.Image) error { return tiff.Encode(w, img, nil) })
+	bmpImg := path.Join(dir, "final.bmp")
+	writeEncodedImage(t, bmpImg, original.Image(), bmp.Encode)
+
+	err = execute("prove", "reencode", "--original-image=../sample/original.png", "--final-image="+tiffImg, "--proof-dir="+dir)
+	require.NoError(t, err)
+
+	originalHash := "--original-hash=" + imageHash(t, "../sample/original.png")
+
+	// The final image can be provided in any format with the same pixels.
+	for _, finalImg := range []string{tiffImg, bmpImg, "../sample/original.png"} {
+		err = execute("verify", "reencode", "--final-image="+finalImg, "--proof-dir="+dir, originalHash)
+		require.NoError(t, err)
+
+		err = execute("verify", "--bundle="+path.Join(dir, "reencode"), "--final-image="+finalImg, originalHash)
+		require.NoError(t, err)
+	}
+
+	// Lossy re-encodings change the pixels.
+	jpegImg := path.Join(dir, "final.jpeg")
+	writeEncodedImage(t, jpegImg, original.Image(), func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) })
+
+	err = execute("verify", "reencode", "--final-image="+jpegImg, "--proof-dir="+dir, originalHash)
+	require.Error(t, err)
+
+	err = execute("prove", "reencode", "--original-image=../sample/original.png", "--final-image="+jpegImg, "--proof-dir="+t.TempDir())
+	require.Error(t, err)
+}
+
+func TestAlpha(t *testing.T) {
+	dir := t.TempDir()
+
+	// The left half of the original image is opaque, the right half semi-transparent.
+	original := transform.NewPixels(transform.Shape{Width: 6, Height: 4, Alpha: true})
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			original.Values[i][j] = []uint16{uint16(40 * i), uint16(40 * j), 200, 255}
+			if j >= 3 {
+				original.Values[i][j][3] = 128
+			}
+		}
+	}
+
+	originalImg := writeImage(t, path.Join(dir, "original.png"), original)
+	originalHash := "--original-hash=" + imageHash(t, originalImg)
+
+	// Crops of the opaque half are opaque images, proven with an opaque alpha channel.
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	cropped, err := crop.Apply(original, transform.Params{"width-new": "3"}.WithDefaults(crop))
+	require.NoError(t, err)
+
+	croppedImg := writeImage(t, path.Join(dir, "cropped.png"), cropped)
+
+	err = execute("setup", "crop", "--width=6", "--height=4", "--final-width=3", "--final-height=4", "--alpha", "--key-dir="+dir)
+	require.NoError(t, err)
+
+	err = execute("prove", "crop", "--original-image="+originalImg, "--final-image="+croppedImg, "--proof-dir="+dir, "--width-new=3", "--proving-key="+path.Join(dir, "crop", "pkey.bin"))
+	require.NoError(t, err)
+
+	verify := []string{"verify", "crop", "--final-image=" + croppedImg, "--proof-dir=" + dir, originalHash, "--verifying-key=" + path.Join(dir, "crop", "vkey.bin"), "--width-start-new=0", "--height-start-new=0", "--original-width=6", "--original-height=4"}
+	require.NoError(t, execute(append(verify, "--alpha")...))
+	require.Error(t, execute(verify...))
+
+	// Color transformations keep the alpha channel.
+	grayscale, err := transform.Get("grayscale")
+	require.NoError(t, err)
+
+	gray, err := grayscale.Apply(original, transform.Params{}.WithDefaults(grayscale))
+	require.NoError(t, err)
+
+	grayImg := writeImage(t, path.Join(dir, "gray.png"), gray)
+
+	err = execute("prove", "grayscale", "--original-image="+originalImg, "--final-image="+grayImg, "--proof-dir="+dir)
+	require.NoError(t, err)
+
+	require.NoError(t, execute("verify", "grayscale", "--final-image="+grayImg, "--proof-dir="+dir, originalHash, "--alpha"))
+	require.NoError(t, execute("verify", "--bundle="+path.Join(dir, "grayscale"), "--final-image="+grayImg, originalHash))
+
+	// The alpha channel is proven, so it must not change.
+	gray.Values[0][3][3] = 255
+	tamperedImg := writeImage(t, path.Join(dir, "tampered.png"), gray)
+	require.Error(t, execute("verify", "grayscale", "--final-image="+tamperedImg, "--proof-dir="+dir, originalHash, "--alpha"))
+}
+
+func TestDepth16(t *testing.T) {
+	dir := t.TempDir()
+
+	// The low bits of the 16-bit original image are lost at 8 bits.
+	original := transform.NewPixels(transform.Shape{Width: 6, Height: 4, Depth16: true})
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			original.Values[i][j] = []uint16{uint16(10000*i + j), uint16(10000 * j), uint16(65535 - i)}
+		}
+	}
+
+	originalImg := writeImage(t, path.Join(dir, "original.png"), original)
+	originalHash := "--original-hash=" + imageHash(t, originalImg)
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	cropped, err := crop.Apply(original, transform.Params{"width-start-new": "1", "width-new": "3", "height-new": "3"}.WithDefaults(crop))
+	require.NoError(t, err)
+
+	croppedImg := writeImage(t, path.Join(dir, "cropped.png"), cropped)
+
+	err = execute("setup", "crop", "--width=6", "--height=4", "--final-width=3", "--final-height=3", "--bit-depth=16", "--key-dir="+dir)
+	require.NoError(t, err)
+
+	err = execute("prove", "crop", "--original-image="+originalImg, "--final-image="+croppedImg, "--proof-dir="+dir, "--width-start-new=1", "--width-new=3", "--height-new=3", "--proving-key="+path.Join(dir, "crop", "pkey.bin"))
+	require.NoError(t, err)
+
+	verify := []string{"verify", "crop", "--final-image=" + croppedImg, "--proof-dir=" + dir, originalHash, "--verifying-key=" + path.Join(dir, "crop", "vkey.bin"), "--width-start-new=1", "--height-start-new=0", "--original-width=6", "--original-height=4"}
+	require.NoError(t, execute(verify...))
+	require.NoError(t, execute("verify", "--bundle="+path.Join(dir, "crop"), "--final-image="+croppedImg, originalHash))
+
+	// Final images converted to 8 bits lost the low bits.
+	require.Error(t, execute(append(verify, "--bit-depth=8")...))
+	require.Error(t, execute(append(verify, "--bit-depth=12")...))
+
+	// The keys only prove images with 16-bit channels.
+	err = execute("prove", "crop", "--original-image="+originalImg, "--final-image="+croppedImg, "--proof-dir="+dir, "--width-start-new=1", "--width-new=3", "--height-new=3", "--bit-depth=8", "--proving-key="+path.Join(dir, "crop", "pkey.bin"))
+	require.Error(t, err)
+}
+
+// execute runs the maya command with the provided arguments.
+func execute(args ...string) error {
+	root := New()
+	root.SetArgs(args)
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+
+	return root.ExecuteContext(context.Background())
+}
+
+// writeEncodedImage writes the image encoded by encode to the provided path.
+func writeEncodedImage(t *testing.T, path string, img image.Image, encode func(io.Writer, image.Image) error) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, encode(f, img))
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+// keysGenerateConfig specifies the configuration for generating a signing key.
+type keysGenerateConfig struct {
+	privateKey string
+}
+
+// keysSignImageConfig specifies the configuration for signing an image.
+type keysSignImageConfig struct {
+	privateKey string
+	image      string
+	signature  string
+	format     string
+	bitDepth   int
+}
+
+// newKeysCmd returns a new cobra.Command for managing image signing keys.
+func newKeysCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "keys",
+		Short: "Manages image signing keys.",
+		Long:  "Manages EdDSA (BabyJubJub over BN254) keys used to sign original images, e.g. by a capture device.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newKeysGenerateCmd returns a new cobra.Command for generating a signing key.
+func newKeysGenerateCmd() *cobra.Command {
+	var conf keysGenerateConfig
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generates a new image signing key.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generateKey(conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&conf.privateKey, "private-key", "", "The path to write the hex encoded private key to.")
+
+	return cmd
+}
+
+// newKeysSignImageCmd returns a new cobra.Command for signing an image.
+func newKeysSignImageCmd() *cobra.Command {
+	var conf keysSignImageConfig
+
+	cmd := &cobra.Command{
+		Use:   "sign-image",
+		Short: "Signs the hash of an image with a local signing key.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return signImage(conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&conf.privateKey, "private-key", "", "The path to the hex encoded private key.")
+	cmd.Flags().StringVar(&conf.image, "image", "", "The path to the image to sign. "+imageFormatsUsage)
+	cmd.Flags().StringVar(&conf.signature, "signature", "", "The path to write the hex encoded signature to.")
+	bindFormatFlag(cmd, &conf.format)
+	bindBitDepthFlag(cmd, &conf.bitDepth)
+
+	return cmd
+}
+
+// generateKey generates a new signing key and prints its public key.
+func generateKey(config keysGenerateConfig) error {
+	if config.privateKey == "" {
+		return errors.New("private key path is required")
+	}
+
+	privKey, err := eddsa.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(config.privateKey, []byte(hex.EncodeToString(privKey.Bytes())), 0o600); err != nil {
+		return err
+	}
+
+	fmt.Println("Signer public key: ", hex.EncodeToString(privKey.PublicKey.Bytes()))
+
+	return nil
+}
+
+// signImage signs the hash of the image with the private key.
+func signImage(config keysSignImageConfig) error {
+	b, err := readHexFile(config.privateKey)
+	if err != nil {
+		return err
+	}
+
+	var privKey eddsa.PrivateKey
+	if _, err = privKey.SetBytes(b); err != nil {
+		return fmt.Errorf("invalid private key, %w", err)
+	}
+
+	pixels, err := loadPixels(config.image, config.format, config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	hash, err := pixels.Hash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := transform.SignHash(&privKey, hash)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(config.signature, []byte(hex.EncodeToString(sig)), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Image hash: ", transform.FormatHash(hash))
+	fmt.Println("Signer public key: ", hex.EncodeToString(privKey.PublicKey.Bytes()))
+
+	return nil
+}
+
+// loadSignature returns the signature of the original image for the provided signer public key and
+// signature file, or nil if the public key is empty, i.e. the original image is not signed.
+func loadSignature(publicKeyHex, signaturePath string) (*maya.Signature, error) {
+	if publicKeyHex == "" {
+		return nil, nil
+	}
+
+	pubKey, err := parsePublicKey(publicKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	if signaturePath == "" {
+		return nil, errors.New("signature is required when signer public key is provided")
+	}
+
+	sig, err := readHexFile(signaturePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &maya.Signature{PublicKey: pubKey, Signature: sig}, nil
+}
+
+// parsePublicKey parses a hex encoded compressed EdDSA public key.
+func parsePublicKey(s string) (*eddsa.PublicKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer public key, %w", err)
+	}
+
+	var pubKey eddsa.PublicKey
+	if _, err := pubKey.SetBytes(b); err != nil {
+		return nil, fmt.Errorf("invalid signer public key, %w", err)
+	}
+
+	return &pubKey, nil
+}
+
+// readHexFile returns the hex decoded content of the file.
+func readHexFile(path string) ([]byte, error) {
+	b, err := readFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"))
+}
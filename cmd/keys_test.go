@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestSignedCrop(t *testing.T) {
+	dir := t.TempDir()
+	privateKey := path.Join(dir, "signer.key")
+	signature := path.Join(dir, "original.sig")
+
+	err := generateKey(keysGenerateConfig{privateKey: privateKey})
+	require.NoError(t, err)
+
+	err = signImage(keysSignImageConfig{
+		privateKey: privateKey,
+		image:      "../sample/original.png",
+		signature:  signature,
+	})
+	require.NoError(t, err)
+
+	publicKey := signerPublicKey(t, privateKey)
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	conf := proveConfig{
+		originalImg:     "../sample/original.png",
+		finalImg:        "../sample/cropped2.png",
+		params:          transform.Params{"width-start-new": "2", "height-start-new": "2"},
+		proofDir:        dir,
+		backend:         "groth16",
+		signerPublicKey: publicKey,
+		signature:       signature,
+	}
+
+	err = prove(context.Background(), crop, conf)
+	require.NoError(t, err)
+
+	verifyConf := verifyConfig{
+		finalImg:        "../sample/cropped2.png",
+		proofDir:        dir,
+		originalHash:    imageHash(t, "../sample/original.png"),
+		signerPublicKey: publicKey,
+		backend:         "groth16",
+		params:          transform.Params{"width-start-new": "2", "height-start-new": "2"},
+	}
+
+	err = verify(context.Background(), crop, verifyConf)
+	require.NoError(t, err)
+
+	// The proof must not verify for a different signer.
+	otherKey, err := eddsa.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	verifyConf.signerPublicKey = hex.EncodeToString(otherKey.PublicKey.Bytes())
+	err = verify(context.Background(), crop, verifyConf)
+	require.Error(t, err)
+
+	// The proof must not verify without the signer.
+	verifyConf.signerPublicKey = ""
+	err = verify(context.Background(), crop, verifyConf)
+	require.Error(t, err)
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	dir := t.TempDir()
+	privateKey := path.Join(dir, "signer.key")
+	signature := path.Join(dir, "brightened.sig")
+
+	err := generateKey(keysGenerateConfig{privateKey: privateKey})
+	require.NoError(t, err)
+
+	// Sign a different image than the original.
+	err = signImage(keysSignImageConfig{
+		privateKey: privateKey,
+		image:      "../sample/brightened.png",
+		signature:  signature,
+	})
+	require.NoError(t, err)
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	conf := proveConfig{
+		originalImg:     "../sample/original.png",
+		finalImg:        "../sample/cropped2.png",
+		params:          transform.Params{"width-start-new": "2", "height-start-new": "2"},
+		proofDir:        dir,
+		backend:         "groth16",
+		signerPublicKey: signerPublicKey(t, privateKey),
+		signature:       signature,
+	}
+
+	err = prove(context.Background(), crop, conf)
+	require.ErrorContains(t, err, "signature does not match")
+}
+
+// signerPublicKey returns the hex encoded public key of the private key at the provided path.
+func signerPublicKey(t *testing.T, privateKey string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(privateKey)
+	require.NoError(t, err)
+
+	raw, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	require.NoError(t, err)
+
+	var key eddsa.PrivateKey
+	_, err = key.SetBytes(raw)
+	require.NoError(t, err)
+
+	return hex.EncodeToString(key.PublicKey.Bytes())
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+// paramValue is a pflag.Value that stores a transformation parameter in params.
+type paramValue struct {
+	param  transform.Param
+	params transform.Params
+}
+
+func (v paramValue) String() string {
+	return v.params[v.param.Name]
+}
+
+func (v paramValue) Set(s string) error {
+	var err error
+	switch v.param.Kind {
+	case transform.ParamInt:
+		_, err = strconv.Atoi(s)
+	case transform.ParamFloat:
+		_, err = strconv.ParseFloat(s, 64)
+	}
+	if err != nil {
+		return err
+	}
+
+	v.params[v.param.Name] = s
+
+	return nil
+}
+
+func (v paramValue) Type() string {
+	switch v.param.Kind {
+	case transform.ParamInt:
+		return "int"
+	case transform.ParamFloat:
+		return "float"
+	default:
+		return "string"
+	}
+}
+
+// bindParamFlags binds a flag for every parameter of the transformation, storing the values in params.
+func bindParamFlags(cmd *cobra.Command, t transform.Transformation, params transform.Params) {
+	for _, param := range t.Params() {
+		params[param.Name] = param.Default
+		cmd.Flags().Var(paramValue{param: param, params: params}, param.Name, param.Usage)
+	}
+}
+
+// bindPublicParamFlags binds a flag for every public parameter of the transformation, storing the values in params.
+// The flags are required unless the parameter is optional.
+func bindPublicParamFlags(cmd *cobra.Command, t transform.Transformation, params transform.Params) {
+	for _, param := range t.Params() {
+		if !param.Public {
+			continue
+		}
+
+		params[param.Name] = param.Default
+		cmd.Flags().Var(paramValue{param: param, params: params}, param.Name, param.Usage+" Printed by prove.")
+		if !param.Optional {
+			_ = cmd.MarkFlagRequired(param.Name)
+		}
+	}
+}
+
+// printPublicParams prints the values of the public parameters of the transformation, which verifiers must provide.
+// Optional parameters are omitted if empty.
+func printPublicParams(t transform.Transformation, params transform.Params) {
+	for _, param := range t.Params() {
+		if param.Public && (!param.Optional || params[param.Name] != "") {
+			fmt.Printf("Public parameter: --%s=%s\n", param.Name, params[param.Name])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+)
+
+// proveConfig specifies the configuration for proving a transformation.
+type proveConfig struct {
+	originalImg     string
+	finalImg        string
+	proofDir        string
+	markdownFile    string
+	backend         string
+	provingKey      string
+	verifyingKey    string
+	signerPublicKey string
+	signature       string
+	embed           bool
+	format          string
+	bitDepth        int
+	params          transform.Params
+}
+
+// newProveTransformationCmd returns a new cobra.Command for proving the transformation.
+func newProveTransformationCmd(t transform.Transformation) *cobra.Command {
+	conf := proveConfig{params: make(transform.Params)}
+
+	cmd := &cobra.Command{
+		Use:   t.Name(),
+		Short: t.Description(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return prove(cmd.Context(), t, conf)
+		},
+	}
+
+	bindProveFlags(cmd, &conf)
+	bindParamFlags(cmd, t, conf.params)
+
+	return cmd
+}
+
+// bindProveFlags binds the prove configuration flags.
+func bindProveFlags(cmd *cobra.Command, conf *proveConfig) {
+	cmd.Flags().StringVar(&conf.originalImg, "original-image", "", "The path to the original image. "+imageFormatsUsage)
+	cmd.Flags().StringVar(&conf.finalImg, "final-image", "", "The path to the final image. "+imageFormatsUsage)
+	cmd.Flags().StringVar(&conf.proofDir, "proof-dir", "", "The path to the proof directory.")
+	cmd.Flags().StringVar(&conf.backend, "backend", transform.BackendGroth16, "The proving backend used for generating the proofs.")
+	cmd.Flags().StringVar(&conf.provingKey, "proving-key", "", "The path to the proving key generated by setup. If empty, an insecure single-use setup is run.")
+	cmd.Flags().StringVar(&conf.verifyingKey, "verifying-key", "", "The path to the verifying key generated by setup with the proving key. Optional, its hash is recorded in the manifest.")
+	cmd.Flags().StringVar(&conf.signerPublicKey, "signer-public-key", "", "The hex encoded public key of the original image signer, e.g. a capture device. Optional.")
+	cmd.Flags().StringVar(&conf.signature, "signature", "", "The path to the hex encoded signature of the original image by the signer.")
+	cmd.Flags().BoolVar(&conf.embed, "embed", false, "Embed the proof bundle into the final image, which must be a PNG. The pixels are unchanged. Only the verifying key hash is embedded with --proving-key, otherwise the verifying key of the insecure setup.")
+	bindFormatFlag(cmd, &conf.format)
+	bindBitDepthFlag(cmd, &conf.bitDepth)
+}
+
+// prove generates the zk proof of the transformation.
+func prove(ctx context.Context, t transform.Transformation, config proveConfig) error {
+	if config.embed {
+		if config.provingKey != "" && config.verifyingKey == "" {
+			return errors.New("embedding a proof generated with a proving key requires its verifying key")
+		}
+
+		if err := checkPNG(config.finalImg); err != nil {
+			return err
+		}
+	}
+
+	var pk []byte
+	if config.provingKey != "" {
+		var err error
+		pk, err = readFromFile(config.provingKey)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("No proving key provided, running an insecure single-use setup. See the setup command.")
+	}
+
+	var vk []byte
+	if config.verifyingKey != "" {
+		var err error
+		vk, err = readFromFile(config.verifyingKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	prover, err := maya.NewProver(config.backend, pk)
+	if err != nil {
+		return err
+	}
+
+	originalImage, err := loadImage(config.originalImg, config.format, config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	finalImage, err := loadImage(config.finalImg, config.format, config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	signature, err := loadSignature(config.signerPublicKey, config.signature)
+	if err != nil {
+		return err
+	}
+
+	proof, err := prover.Prove(ctx, t.Name(), originalImage, finalImage, config.params, signature)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Original image hash: ", transform.FormatHash(proof.OriginalHash))
+	printPublicParams(t, proof.Params)
+	if proof.Original.Alpha {
+		fmt.Println("Original image has an alpha channel, verify with --alpha")
+	}
+	if proof.Original.Depth16 {
+		fmt.Println("Original image has 16-bit channels, verify with --bit-depth=16")
+	}
+	fmt.Printf("%s circuit compilation time: %vs\n", t.Name(), proof.CompileTime.Seconds())
+	fmt.Printf("Time taken to prove: %vs\n", proof.ProveTime.Seconds())
+
+	dir := path.Join(config.proofDir, t.Name())
+	if err = os.MkdirAll(dir, 0o777); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(path.Join(dir, "proof.bin"), proof.Proof, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Proof size: ", len(proof.Proof))
+
+	// Proofs generated with a proving key are verified with the matching verifying key generated by setup.
+	if proof.VerifyingKey != nil {
+		if err = os.WriteFile(path.Join(dir, "vkey.bin"), proof.VerifyingKey, 0o644); err != nil {
+			return err
+		}
+
+		fmt.Println("Verifying key size: ", len(proof.VerifyingKey))
+	}
+
+	manifest := maya.NewManifest(proof, finalImage, vk)
+	if err = writeManifest(dir, manifest); err != nil {
+		return err
+	}
+
+	fmt.Println("Proof bundle written to", dir)
+
+	if config.embed {
+		// The verifying key of an insecure setup is not distributed otherwise, so it is embedded, see verify.
+		bundle, err := maya.NewBundle(*manifest, proof.Proof, proof.VerifyingKey, proof.VerifyingKey != nil)
+		if err != nil {
+			return err
+		}
+
+		if err = embedBundle(config.finalImg, bundle); err != nil {
+			return err
+		}
+
+		fmt.Println("Proof bundle embedded into", config.finalImg)
+	}
+
+	if config.markdownFile != "" {
+		mdFile, err := os.OpenFile(config.markdownFile, os.O_APPEND|os.O_RDWR|os.O_CREATE, 0755)
+		if err != nil {
+			return err
+		}
+		defer mdFile.Close()
+
+		if _, err = fmt.Fprintf(mdFile, "| %s | %s | %f | %f | %d | %d | %s |\n",
+			proof.Original,
+			proof.Final,
+			proof.CompileTime.Seconds(),
+			proof.ProveTime.Seconds(),
+			len(proof.Proof),
+			len(proof.VerifyingKey),
+			config.backend,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
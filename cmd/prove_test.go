@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"image/png"
+	"os"
+	"path"
+	"testing"
+)
+
+var resultsDir = flag.String("results-dir", "../book/perf/mbp", "Configures the markdown file to write test results.")
+
+// benchmarkCase is a benchmark of proving a transformation of the top-left square of the 1000x1000 sample image.
+type benchmarkCase struct {
+	name         string
+	originalSize int
+	params       transform.Params
+}
+
+// benchmarkCases returns cases for original images of increasing size with the same parameters.
+func benchmarkCases(params transform.Params) []benchmarkCase {
+	var resp []benchmarkCase
+	for _, size := range []struct {
+		name string
+		size int
+	}{
+		{"xsmall", 10},
+		{"small", 100},
+		{"medium", 250},
+		{"large", 500},
+		{"xlarge", 750},
+	} {
+		resp = append(resp, benchmarkCase{name: size.name, originalSize: size.size, params: params})
+	}
+
+	return resp
+}
+
+func TestBenchmarkCrop(t *testing.T) {
+	var cases []benchmarkCase
+	for _, c := range benchmarkCases(nil) {
+		// Crop the full sample image to an image of increasing size.
+		size := fmt.Sprint(c.originalSize)
+		cases = append(cases, benchmarkCase{
+			name:         c.name,
+			originalSize: 1000,
+			params:       transform.Params{"width-new": size, "height-new": size},
+		})
+	}
+
+	benchmark(t, "crop", "Crop", cases)
+}
+
+func TestBenchmarkRotate90(t *testing.T) {
+	benchmark(t, "rotate90", "Rotate 90", benchmarkCases(nil))
+}
+
+func TestBenchmarkRotate180(t *testing.T) {
+	benchmark(t, "rotate180", "Rotate 180", benchmarkCases(nil))
+}
+
+func TestBenchmarkRotate270(t *testing.T) {
+	benchmark(t, "rotate270", "Rotate 270", benchmarkCases(nil))
+}
+
+func TestBenchmarkFlipVertical(t *testing.T) {
+	benchmark(t, "flip-vertical", "Flip vertical", benchmarkCases(nil))
+}
+
+func TestBenchmarkFlipHorizontal(t *testing.T) {
+	benchmark(t, "flip-horizontal", "Flip horizontal", benchmarkCases(nil))
+}
+
+func TestBenchmarkBrighten(t *testing.T) {
+	benchmark(t, "brighten", "Brighten", benchmarkCases(transform.Params{"brightening-factor": "2"})[:1])
+}
+
+// benchmark proves the benchmark cases of the transformation with every backend and writes the results to a markdown table.
+func benchmark(t *testing.T, name, title string, cases []benchmarkCase) {
+	// Proving the larger images needs more memory and time than a regular test run has.
+	if testing.Short() || os.Getenv("MAYA_BENCHMARK") == "" {
+		t.Skip("set MAYA_BENCHMARK=1 to run the benchmarks")
+	}
+
+	tr, err := transform.Get(name)
+	require.NoError(t, err)
+
+	mdFilePath := path.Join(*resultsDir, name+".md")
+	mdFile, err := os.Create(mdFilePath)
+	require.NoError(t, err)
+
+	fmt.Fprintln(mdFile, "## "+title)
+	if name == "brighten" {
+		fmt.Fprintf(mdFile, "#### Brightness factor: %s\n", cases[0].params["brightening-factor"])
+	}
+
+	// Write the Markdown table headers
+	fmt.Fprintln(mdFile, "| Original Size | Final Size | Circuit compilation (s) | Proving time (s) | Proof size (bytes) | Verifying Key size (bytes) | Backend |")
+	fmt.Fprintln(mdFile, "|---|---|---|---|---|---|---|")
+	mdFile.Close()
+
+	for _, backend := range []string{transform.BackendGroth16, transform.BackendPlonk} {
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%s_%s_%s", name, c.name, backend), func(t *testing.T) {
+				dir := t.TempDir()
+
+				original := cropPixels(t, "../sample/original-1000x1000.png", c.originalSize)
+
+				final, err := tr.Apply(original, c.params.WithDefaults(tr))
+				require.NoError(t, err)
+
+				conf := proveConfig{
+					originalImg:  writeImage(t, path.Join(dir, "original.png"), original),
+					finalImg:     writeImage(t, path.Join(dir, "final.png"), final),
+					proofDir:     dir,
+					markdownFile: mdFilePath,
+					backend:      backend,
+					params:       c.params,
+				}
+
+				err = prove(context.Background(), tr, conf)
+				require.NoError(t, err)
+			})
+		}
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		final   string
+		params  transform.Params
+		backend string
+		// invalid are different public parameters the proof must not verify with, if any.
+		invalid transform.Params
+	}{
+		{
+			name:    "crop",
+			final:   "../sample/cropped2.png",
+			params:  transform.Params{"width-start-new": "2", "height-start-new": "2"},
+			backend: transform.BackendGroth16,
+			invalid: transform.Params{"width-start-new": "2", "height-start-new": "1"},
+		},
+		{
+			name:    "crop",
+			final:   "../sample/cropped.png",
+			backend: transform.BackendPlonk,
+		},
+		{
+			name:    "rotate90",
+			final:   "../sample/rotated90.png",
+			backend: transform.BackendGroth16,
+		},
+		{
+			name:    "rotate180",
+			final:   "../sample/rotated180.png",
+			backend: transform.BackendGroth16,
+		},
+		{
+			name:    "rotate270",
+			final:   "../sample/rotated270.png",
+			backend: transform.BackendGroth16,
+		},
+		{
+			name:    "flip-vertical",
+			final:   "../sample/flipped_vertical.png",
+			backend: transform.BackendGroth16,
+		},
+		{
+			name:    "flip-horizontal",
+			final:   "../sample/flipped_horizontal.png",
+			backend: transform.BackendPlonk,
+		},
+		{
+			name:    "dihedral",
+			final:   "../sample/transposed.png",
+			params:  transform.Params{"symmetry": "transpose"},
+			backend: transform.BackendGroth16,
+			invalid: transform.Params{"symmetry": "rotate90"},
+		},
+		{
+			name:    "brighten",
+			final:   "../sample/brightened.png",
+			params:  transform.Params{"brightening-factor": "2"},
+			backend: transform.BackendGroth16,
+			invalid: transform.Params{"brightening-factor": "3"},
+		},
+		{
+			name:    "color-matrix",
+			final:   "../sample/sepia.png",
+			backend: transform.BackendPlonk,
+			invalid: transform.Params{"matrix": "invert"},
+		},
+		{
+			name:    "convolve",
+			final:   "../sample/blurred.png",
+			backend: transform.BackendGroth16,
+			invalid: transform.Params{"kernel": "blur3", "divisor": "15"},
+		},
+		{
+			name:    "lut",
+			final:   "../sample/gamma.png",
+			params:  transform.Params{"gamma": "2.2"},
+			backend: transform.BackendGroth16,
+			invalid: transform.Params{"gamma": "2"},
+		},
+		{
+			name:    "redact",
+			final:   "../sample/redacted.png",
+			params:  transform.Params{"regions": "4x3+2+2,2x2+7+7", "fill": "pixelate", "block-size": "2"},
+			backend: transform.BackendPlonk,
+			invalid: transform.Params{"regions": "4x3+2+2,2x2+6+7", "fill": "pixelate", "block-size": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"_"+tt.backend, func(t *testing.T) {
+			tr, err := transform.Get(tt.name)
+			require.NoError(t, err)
+
+			proofDir := t.TempDir()
+			conf := proveConfig{
+				originalImg: "../sample/original.png",
+				finalImg:    tt.final,
+				proofDir:    proofDir,
+				backend:     tt.backend,
+				params:      tt.params,
+			}
+
+			err = prove(context.Background(), tr, conf)
+			require.NoError(t, err)
+
+			verifyConf := verifyConfig{
+				finalImg:     tt.final,
+				proofDir:     proofDir,
+				originalHash: imageHash(t, "../sample/original.png"),
+				backend:      tt.backend,
+				params:       tt.params,
+			}
+
+			err = verify(context.Background(), tr, verifyConf)
+			require.NoError(t, err)
+
+			// The proof must not verify for a different original image.
+			verifyConf.originalHash = imageHash(t, "../sample/brightened.png")
+			err = verify(context.Background(), tr, verifyConf)
+			require.Error(t, err)
+
+			// The proof must not verify for different public parameters.
+			if tt.invalid != nil {
+				verifyConf.originalHash = imageHash(t, "../sample/original.png")
+				verifyConf.params = tt.invalid
+				err = verify(context.Background(), tr, verifyConf)
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestProveInvalidFinalImage(t *testing.T) {
+	tr, err := transform.Get("rotate90")
+	require.NoError(t, err)
+
+	conf := proveConfig{
+		originalImg: "../sample/original.png",
+		finalImg:    "../sample/cropped2.png",
+		proofDir:    t.TempDir(),
+		backend:     transform.BackendGroth16,
+	}
+
+	err = prove(context.Background(), tr, conf)
+	require.ErrorContains(t, err, "does not match expected")
+}
+
+func TestCommands(t *testing.T) {
+	root := New()
+
+	for _, tr := range transform.All() {
+		for _, parent := range []string{"prove", "verify"} {
+			cmd, _, err := root.Find([]string{parent, tr.Name()})
+			require.NoError(t, err)
+			require.Equal(t, tr.Name(), cmd.Name())
+		}
+
+		cmd, _, err := root.Find([]string{"prove", tr.Name()})
+		require.NoError(t, err)
+
+		for _, param := range tr.Params() {
+			flag := cmd.Flags().Lookup(param.Name)
+			require.NotNil(t, flag, param.Name)
+			require.Equal(t, param.Default, flag.DefValue)
+		}
+	}
+}
+
+// imageHash returns the formatted hash of the image at the provided path.
+func imageHash(t *testing.T, path string) string {
+	t.Helper()
+
+	pixels, err := loadPixels(path, "", 0)
+	require.NoError(t, err)
+
+	hash, err := pixels.Hash()
+	require.NoError(t, err)
+
+	return transform.FormatHash(hash)
+}
+
+// cropPixels returns the pixels of the top-left square of the provided size of the image.
+func cropPixels(t *testing.T, original string, size int) transform.Pixels {
+	t.Helper()
+
+	pixels, err := loadPixels(original, "", 0)
+	require.NoError(t, err)
+
+	pixels.Values = pixels.Values[:size]
+	for i := range pixels.Values {
+		pixels.Values[i] = pixels.Values[i][:size]
+	}
+
+	return pixels
+}
+
+// writeImage writes the pixels as a PNG image to the provided path and returns the path.
+func writeImage(t *testing.T, path string, pixels transform.Pixels) string {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, png.Encode(f, pixels.Image()))
+
+	return path
+}
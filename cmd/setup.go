@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"path"
+)
+
+// setupConfig specifies the configuration for generating the keys of a transformation circuit.
+type setupConfig struct {
+	width       int
+	height      int
+	finalWidth  int
+	finalHeight int
+	alpha       bool
+	bitDepth    int
+	backend     string
+	signed      bool
+	srs         string
+	keyDir      string
+	params      transform.Params
+}
+
+// newSetupCmd returns a new cobra.Command for generating the keys of transformation circuits.
+func newSetupCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "setup",
+		Short: "Generates proving and verifying keys for the specified transformation.",
+		Long:  "Generates the proving and verifying keys of the transformation circuit for fixed image dimensions, so they can be reused by prove and verify.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newSetupTransformationCmd returns a new cobra.Command for generating the keys of the transformation circuit.
+func newSetupTransformationCmd(t transform.Transformation) *cobra.Command {
+	conf := setupConfig{params: make(transform.Params)}
+
+	cmd := &cobra.Command{
+		Use:   t.Name(),
+		Short: t.Description(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setup(cmd.Context(), t, conf)
+		},
+	}
+
+	cmd.Flags().IntVar(&conf.width, "width", 0, "The width of the original image.")
+	cmd.Flags().IntVar(&conf.height, "height", 0, "The height of the original image.")
+	cmd.Flags().IntVar(&conf.finalWidth, "final-width", 0, "The width of the final image. Derived from the original image and parameters if zero.")
+	cmd.Flags().IntVar(&conf.finalHeight, "final-height", 0, "The height of the final image. Derived from the original image and parameters if zero.")
+	cmd.Flags().BoolVar(&conf.alpha, "alpha", false, "Whether the original image has an alpha channel, see prove.")
+	bindBitDepthFlag(cmd, &conf.bitDepth)
+	cmd.Flags().StringVar(&conf.backend, "backend", transform.BackendGroth16, "The proving backend used for generating the proofs.")
+	cmd.Flags().BoolVar(&conf.signed, "signed", false, "Generate keys for proofs of signed original images, see prove --signer-public-key.")
+	cmd.Flags().StringVar(&conf.srs, "srs", "", "The path to the KZG SRS of a powers of tau ceremony, in snarkjs .ptau or gnark format. Required by the plonk backend.")
+	cmd.Flags().StringVar(&conf.keyDir, "key-dir", "", "The path to the directory to write the proving and verifying keys to.")
+	_ = cmd.MarkFlagRequired("width")
+	_ = cmd.MarkFlagRequired("height")
+	bindParamFlags(cmd, t, conf.params)
+
+	return cmd
+}
+
+// setup generates the proving and verifying keys of the transformation circuit.
+func setup(ctx context.Context, t transform.Transformation, config setupConfig) error {
+	depth16, err := bitDepth16(config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	original := transform.Shape{Width: config.width, Height: config.height, Alpha: config.alpha, Depth16: depth16}
+	final := transform.Shape{Width: config.finalWidth, Height: config.finalHeight}
+
+	var srs io.Reader
+	if config.srs != "" {
+		file, err := os.Open(config.srs)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		srs = file
+	}
+
+	keys, err := maya.Setup(ctx, config.backend, t.Name(), original, final, config.params, config.signed, srs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s setup time: %vs\n", t.Name(), keys.SetupTime.Seconds())
+
+	return writeKeys(keys, config.keyDir)
+}
+
+// writeKeys writes the proving and verifying keys to the transformation directory in the key directory.
+func writeKeys(keys *maya.Keys, keyDir string) error {
+	dir := path.Join(keyDir, keys.Transformation)
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path.Join(dir, "pkey.bin"), keys.ProvingKey, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Proving key size: ", len(keys.ProvingKey))
+
+	if err := os.WriteFile(path.Join(dir, "vkey.bin"), keys.VerifyingKey, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Verifying key size: ", len(keys.VerifyingKey))
+	fmt.Printf("Keys for %s to %s images written to %s\n", keys.Original, keys.Final, dir)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestSetupProveVerify(t *testing.T) {
+	ctx := context.Background()
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	params := transform.Params{"width-start-new": "2", "height-start-new": "2"}
+
+	for _, backend := range []string{transform.BackendGroth16, transform.BackendPlonk} {
+		t.Run(backend, func(t *testing.T) {
+			dir := t.TempDir()
+
+			setupConf := setupConfig{
+				width:       10,
+				height:      10,
+				finalWidth:  7,
+				finalHeight: 7,
+				backend:     backend,
+				keyDir:      dir,
+				params:      params,
+			}
+
+			if backend == transform.BackendPlonk {
+				// PLONK requires an SRS.
+				err = setup(ctx, crop, setupConf)
+				require.ErrorContains(t, err, "missing kzg srs")
+
+				setupConf.srs = writeSRS(t, dir, 1<<14+3)
+			}
+
+			err = setup(ctx, crop, setupConf)
+			require.NoError(t, err)
+
+			proofDir := path.Join(dir, "proofs")
+			err = prove(ctx, crop, proveConfig{
+				originalImg: "../sample/original.png",
+				finalImg:    "../sample/cropped2.png",
+				proofDir:    proofDir,
+				backend:     backend,
+				provingKey:  path.Join(dir, "crop", "pkey.bin"),
+				params:      params,
+			})
+			require.NoError(t, err)
+
+			// The verifying key is distributed independently of the proof.
+			_, err = os.Stat(path.Join(proofDir, "crop", "vkey.bin"))
+			require.ErrorIs(t, err, os.ErrNotExist)
+
+			verifyConf := verifyConfig{
+				finalImg:     "../sample/cropped2.png",
+				proofDir:     proofDir,
+				originalHash: imageHash(t, "../sample/original.png"),
+				backend:      backend,
+				verifyingKey: path.Join(dir, "crop", "vkey.bin"),
+				params:       transform.Params{"width-start-new": "2", "height-start-new": "2"},
+			}
+
+			err = verify(ctx, crop, verifyConf)
+			require.NoError(t, err)
+
+			verifyConf.verifyingKey = ""
+			err = verify(ctx, crop, verifyConf)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestSetupSRSTooSmall(t *testing.T) {
+	dir := t.TempDir()
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	err = setup(context.Background(), crop, setupConfig{
+		width:   10,
+		height:  10,
+		backend: transform.BackendPlonk,
+		srs:     writeSRS(t, dir, 1<<10),
+		keyDir:  dir,
+		params:  make(transform.Params),
+	})
+	require.ErrorContains(t, err, "the circuit requires")
+}
+
+// writeSRS writes an insecure KZG SRS of the provided size in gnark format and returns its path.
+func writeSRS(t *testing.T, dir string, size int) string {
+	t.Helper()
+
+	srs, err := transform.NewInsecureSRS(size)
+	require.NoError(t, err)
+
+	file, err := os.Create(path.Join(dir, "srs.bin"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = srs.WriteTo(file)
+	require.NoError(t, err)
+
+	return file.Name()
+}
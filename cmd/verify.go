@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+)
+
+// manifestFile is the name of the manifest of a proof bundle, see maya.Manifest.
+const manifestFile = "manifest.json"
+
+// verifyConfig specifies the verification configuration of a transformation.
+type verifyConfig struct {
+	bundle          string
+	proofDir        string
+	finalImg        string
+	originalHash    string
+	signerPublicKey string
+	backend         string
+	verifyingKey    string
+	format          string
+	bitDepth        int
+	alpha           bool
+	params          transform.Params
+}
+
+// newVerifyCmd returns a new cobra.Command for verifying proof bundles and the transformation subcommands.
+func newVerifyCmd(cmds ...*cobra.Command) *cobra.Command {
+	var conf verifyConfig
+
+	root := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies proof for the specified transformation.",
+		Long: "Verifies the zero knowledge proof of transformation on the original image resulting in a new image. " +
+			"Without a subcommand, the transformation, parameters and backend are read from the manifest of the proof bundle, " +
+			"either --bundle or the bundle embedded in the final image.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verifyBundle(cmd.Context(), conf)
+		},
+	}
+
+	root.Flags().StringVar(&conf.bundle, "bundle", "", "The path to the proof bundle directory written by prove, e.g. proofs/crop, or the bundle file written by bundle pack. Defaults to the bundle embedded in the final image by prove --embed.")
+	root.Flags().StringVar(&conf.finalImg, "final-image", "", "The path to the final image. "+imageFormatsUsage)
+	root.Flags().StringVar(&conf.originalHash, "original-hash", "", "The expected hash of the original image. Defaults to the unpinned hash in the manifest.")
+	root.Flags().StringVar(&conf.signerPublicKey, "signer-public-key", "", "The expected hex encoded public key of the original image signer. Defaults to the unpinned signer in the manifest.")
+	root.Flags().StringVar(&conf.verifyingKey, "verifying-key", "", "The path to the verifying key generated by setup. Defaults to the unpinned verifying key in the bundle.")
+	bindFormatFlag(root, &conf.format)
+	bindBitDepthFlag(root, &conf.bitDepth)
+	_ = root.MarkFlagRequired("final-image")
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newVerifyTransformationCmd returns a new cobra.Command for verifying the transformation.
+func newVerifyTransformationCmd(t transform.Transformation) *cobra.Command {
+	conf := verifyConfig{params: make(transform.Params)}
+
+	cmd := &cobra.Command{
+		Use:   t.Name(),
+		Short: t.Description(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verify(cmd.Context(), t, conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&conf.proofDir, "proof-dir", "", "The path to the proof directory.")
+	cmd.Flags().StringVar(&conf.finalImg, "final-image", "", "The path to the final image. "+imageFormatsUsage)
+	cmd.Flags().StringVar(&conf.originalHash, "original-hash", "", "The hash of the original image printed by the prove command.")
+	cmd.Flags().StringVar(&conf.signerPublicKey, "signer-public-key", "", "The hex encoded public key of the original image signer, if the proof was generated with one.")
+	cmd.Flags().StringVar(&conf.backend, "backend", transform.BackendGroth16, "The proof backend used to generate proof. Supported: groth16 and plonk.")
+	cmd.Flags().StringVar(&conf.verifyingKey, "verifying-key", "", "The path to the verifying key generated by setup. Defaults to the verifying key in the proof directory.")
+	bindFormatFlag(cmd, &conf.format)
+	bindBitDepthFlag(cmd, &conf.bitDepth)
+	cmd.Flags().BoolVar(&conf.alpha, "alpha", false, "Whether the original image has an alpha channel, printed by the prove command.")
+	_ = cmd.MarkFlagRequired("original-hash")
+	bindPublicParamFlags(cmd, t, conf.params)
+
+	return cmd
+}
+
+// verify verifies the zk proof of the transformation.
+func verify(ctx context.Context, t transform.Transformation, config verifyConfig) error {
+	originalHash, err := transform.ParseHash(config.originalHash)
+	if err != nil {
+		return err
+	}
+
+	var signer *eddsa.PublicKey
+	if config.signerPublicKey != "" {
+		signer, err = parsePublicKey(config.signerPublicKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	finalImage, err := loadImage(config.finalImg, config.format, config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join(config.proofDir, t.Name())
+
+	proof := &maya.Proof{
+		Transformation: t.Name(),
+		Params:         config.params,
+		Backend:        config.backend,
+	}
+
+	// The alpha channel of the original image is proven, so the final image is verified with one too.
+	if config.alpha {
+		bounds := finalImage.Bounds()
+		proof.Final = transform.Shape{Width: bounds.Dx(), Height: bounds.Dy(), Alpha: true, Depth16: transform.BitDepth(finalImage) == 16}
+	}
+
+	// The original image shape is recorded in the manifest written by prove, so public parameters that default to it,
+	// e.g. the crop dimensions, are resolved from it. The proof binds them, so the manifest is not trusted otherwise.
+	if manifest, err := readManifest(dir); err == nil {
+		proof.Original = manifest.Original
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	proof.Proof, err = readFromFile(path.Join(dir, "proof.bin"))
+	if err != nil {
+		return err
+	}
+
+	vkPath := config.verifyingKey
+	if vkPath == "" {
+		vkPath = path.Join(dir, "vkey.bin")
+	}
+
+	vk, err := readFromFile(vkPath)
+	if err != nil {
+		return err
+	}
+
+	err = maya.NewVerifier(vk).Verify(ctx, proof, finalImage, originalHash, signer)
+	if err != nil {
+		fmt.Println("Invalid proof 😞")
+		return err
+	}
+
+	fmt.Println("Proof verified 🎉")
+
+	return nil
+}
+
+// verifyBundle verifies the zk proof of the bundle directory or file, dispatching on its manifest.
+func verifyBundle(ctx context.Context, config verifyConfig) error {
+	var (
+		bundle *maya.Bundle
+		err    error
+	)
+	if config.bundle != "" {
+		bundle, err = loadBundle(config.bundle, config.verifyingKey)
+	} else {
+		bundle, err = readEmbeddedBundle(config.finalImg)
+	}
+	if err != nil {
+		return err
+	}
+
+	var vk []byte
+	if config.verifyingKey != "" {
+		if vk, err = readFromFile(config.verifyingKey); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("No verifying key provided, trusting the verifying key in the bundle. Anyone can generate keys for their own bundle, pin the key with --verifying-key.")
+	}
+
+	finalImage, err := loadImage(config.finalImg, config.format, config.bitDepth)
+	if err != nil {
+		return err
+	}
+
+	proof, err := bundle.Open(vk, finalImage)
+	if err != nil {
+		fmt.Println("Invalid proof 😞")
+		return err
+	}
+
+	manifest := bundle.Manifest
+	fmt.Printf("Verifying %s proof of %s to %s images with %s, generated by maya %s\n",
+		proof.Transformation, proof.Original, proof.Final, proof.Backend, manifest.MayaVersion)
+
+	originalHash := proof.OriginalHash
+	if config.originalHash != "" {
+		if originalHash, err = transform.ParseHash(config.originalHash); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("No original hash provided, trusting the original image hash in the manifest. Pin it with --original-hash.")
+		fmt.Println("Original image hash from the manifest: ", manifest.OriginalHash)
+	}
+
+	signer := proof.Signer
+	if config.signerPublicKey != "" {
+		if signer, err = parsePublicKey(config.signerPublicKey); err != nil {
+			return err
+		}
+	} else if signer != nil {
+		fmt.Println("No signer public key provided, trusting the signer in the manifest. Pin it with --signer-public-key.")
+		fmt.Println("Signer public key from the manifest: ", manifest.Signer)
+	}
+
+	err = maya.NewVerifier(proof.VerifyingKey).Verify(ctx, proof, finalImage, originalHash, signer)
+	if err != nil {
+		fmt.Println("Invalid proof 😞")
+		return err
+	}
+
+	fmt.Println("Proof verified 🎉")
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/0xmayalabs/maya-cli/pkg/maya"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestVerifyBundle(t *testing.T) {
+	ctx := context.Background()
+	proofDir := t.TempDir()
+
+	crop, err := transform.Get("crop")
+	require.NoError(t, err)
+
+	err = prove(ctx, crop, proveConfig{
+		originalImg: "../sample/original.png",
+		finalImg:    "../sample/cropped2.png",
+		proofDir:    proofDir,
+		backend:     transform.BackendPlonk,
+		params:      transform.Params{"width-start-new": "2", "height-start-new": "2"},
+	})
+	require.NoError(t, err)
+
+	bundle := path.Join(proofDir, "crop")
+	conf := verifyConfig{
+		bundle:   bundle,
+		finalImg: "../sample/cropped2.png",
+	}
+
+	// The transformation, parameters and backend are read from the manifest.
+	err = verifyBundle(ctx, conf)
+	require.NoError(t, err)
+
+	conf.originalHash = imageHash(t, "../sample/original.png")
+	err = verifyBundle(ctx, conf)
+	require.NoError(t, err)
+
+	conf.originalHash = imageHash(t, "../sample/brightened.png")
+	err = verifyBundle(ctx, conf)
+	require.Error(t, err)
+
+	conf.originalHash = ""
+	conf.finalImg = "../sample/cropped.png"
+	err = verifyBundle(ctx, conf)
+	require.ErrorContains(t, err, "does not match the public inputs")
+
+	// Tampering with the public inputs in the manifest invalidates the proof.
+	b, err := os.ReadFile(path.Join(bundle, manifestFile))
+	require.NoError(t, err)
+
+	var manifest maya.Manifest
+	require.NoError(t, json.Unmarshal(b, &manifest))
+	manifest.OriginalHash = imageHash(t, "../sample/brightened.png")
+
+	b, err = json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(bundle, manifestFile), b, 0o644))
+
+	conf.finalImg = "../sample/cropped2.png"
+	err = verifyBundle(ctx, conf)
+	require.Error(t, err)
+}
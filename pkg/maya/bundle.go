@@ -0,0 +1,200 @@
+package maya
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/fxamacker/cbor/v2"
+	"image"
+	"reflect"
+)
+
+// BundleVersion is the version of the bundle format.
+const BundleVersion = 1
+
+// BundleTag is the CBOR tag of bundles, "maya" in ASCII, so encoded bundles start with the bytes 0xda "maya".
+const BundleTag = 0x6d617961
+
+// bundleEncMode and bundleDecMode encode and decode bundles as tagged deterministic CBOR.
+var bundleEncMode, bundleDecMode = bundleModes()
+
+// Bundle is a single-file proof container, holding the manifest, public inputs and proof, and the verifying key
+// or only its hash in the manifest. Bundles are encoded as tagged CBOR, see Marshal.
+type Bundle struct {
+	// Version is the bundle format version, see BundleVersion.
+	Version int `cbor:"1,keyasint"`
+	// Manifest describes the proof, see Manifest.
+	Manifest Manifest `cbor:"2,keyasint"`
+	// PublicInputs are the public inputs of the proof, except the final image. They must match the manifest.
+	PublicInputs PublicInputs `cbor:"3,keyasint"`
+	// Proof is the serialised proof.
+	Proof []byte `cbor:"4,keyasint"`
+	// VerifyingKey is the serialised verifying key, or nil if only its hash is included in the manifest.
+	VerifyingKey []byte `cbor:"5,keyasint,omitempty"`
+}
+
+// PublicInputs are the public inputs of a proof besides the final image, which is distributed separately.
+type PublicInputs struct {
+	// OriginalHash is the big-endian encoding of the original image hash, see transform.Pixels.Hash.
+	OriginalHash []byte `cbor:"1,keyasint"`
+	// Signer is the compressed public key of the original image signer, if any.
+	Signer []byte `cbor:"2,keyasint,omitempty"`
+	// Final is the shape of the final image.
+	Final transform.Shape `cbor:"3,keyasint"`
+	// Params are the public parameters of the transformation, see transform.Param, if any.
+	Params transform.Params `cbor:"4,keyasint,omitempty"`
+}
+
+// NewBundle returns the bundle of the proof described by the manifest. The verifying key is only included if
+// embed is true, otherwise the manifest must include its hash, so verifiers can check the key they pin.
+func NewBundle(manifest Manifest, proof, verifyingKey []byte, embed bool) (*Bundle, error) {
+	if manifest.VerifyingKeyHash == "" && verifyingKey != nil {
+		manifest.VerifyingKeyHash = hash(verifyingKey)
+	}
+
+	switch {
+	case embed && verifyingKey == nil:
+		return nil, errors.New("missing verifying key")
+	case !embed && manifest.VerifyingKeyHash == "":
+		return nil, errors.New("bundle without verifying key requires its hash")
+	case hash(proof) != manifest.ProofHash:
+		return nil, errors.New("proof does not match the manifest")
+	case verifyingKey != nil && hash(verifyingKey) != manifest.VerifyingKeyHash:
+		return nil, errors.New("verifying key does not match the manifest")
+	}
+
+	inputs, err := manifestInputs(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Bundle{
+		Version:      BundleVersion,
+		Manifest:     manifest,
+		PublicInputs: inputs,
+		Proof:        proof,
+	}
+
+	if embed {
+		resp.VerifyingKey = verifyingKey
+	}
+
+	return resp, nil
+}
+
+// UnmarshalBundle decodes a bundle encoded by Marshal.
+func UnmarshalBundle(data []byte) (*Bundle, error) {
+	var resp Bundle
+	if err := bundleDecMode.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid bundle, %w", err)
+	}
+
+	if resp.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d", resp.Version)
+	}
+
+	if err := resp.checkInputs(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Marshal returns the deterministic CBOR encoding of the bundle, tagged with BundleTag.
+func (b *Bundle) Marshal() ([]byte, error) {
+	return bundleEncMode.Marshal(b)
+}
+
+// Open returns the proof of the bundle after checking the public inputs match the manifest and the final image,
+// see Manifest.Proof. The verifying key pins the expected key; if nil, the verifying key included in the bundle is used.
+func (b *Bundle) Open(verifyingKey []byte, final image.Image) (*Proof, error) {
+	if verifyingKey == nil {
+		verifyingKey = b.VerifyingKey
+	}
+
+	if verifyingKey == nil {
+		return nil, errors.New("bundle does not include the verifying key")
+	}
+
+	if err := b.checkInputs(); err != nil {
+		return nil, err
+	}
+
+	bounds := final.Bounds()
+	if bounds.Dx() != b.PublicInputs.Final.Width || bounds.Dy() != b.PublicInputs.Final.Height {
+		return nil, fmt.Errorf("final image %dx%d does not match the public inputs %s", bounds.Dx(), bounds.Dy(), b.PublicInputs.Final)
+	}
+
+	return b.Manifest.Proof(b.Proof, verifyingKey, final)
+}
+
+// checkInputs returns an error if the public inputs do not match the manifest.
+func (b *Bundle) checkInputs() error {
+	inputs, err := manifestInputs(b.Manifest)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(inputs, b.PublicInputs) {
+		return errors.New("public inputs do not match the manifest")
+	}
+
+	return nil
+}
+
+// manifestInputs returns the public inputs described by the manifest.
+func manifestInputs(m Manifest) (PublicInputs, error) {
+	t, err := transform.Get(m.Transformation)
+	if err != nil {
+		return PublicInputs{}, err
+	}
+
+	originalHash, err := transform.ParseHash(m.OriginalHash)
+	if err != nil {
+		return PublicInputs{}, err
+	}
+
+	b := originalHash.FillBytes(make([]byte, fr.Bytes))
+
+	var signer []byte
+	if m.Signer != "" {
+		if signer, err = hex.DecodeString(m.Signer); err != nil {
+			return PublicInputs{}, fmt.Errorf("invalid manifest signer, %w", err)
+		}
+	}
+
+	var params transform.Params
+	for _, param := range t.Params() {
+		if v := m.Params[param.Name]; param.Public && v != "" {
+			if params == nil {
+				params = make(transform.Params)
+			}
+			params[param.Name] = v
+		}
+	}
+
+	return PublicInputs{OriginalHash: b, Signer: signer, Final: m.Final, Params: params}, nil
+}
+
+// bundleModes returns the CBOR encoding and decoding modes of bundles.
+func bundleModes() (cbor.EncMode, cbor.DecMode) {
+	tags := cbor.NewTagSet()
+	opts := cbor.TagOptions{EncTag: cbor.EncTagRequired, DecTag: cbor.DecTagRequired}
+	if err := tags.Add(opts, reflect.TypeOf(Bundle{}), BundleTag); err != nil {
+		panic(err)
+	}
+
+	enc, err := cbor.CoreDetEncOptions().EncModeWithTags(tags)
+	if err != nil {
+		panic(err)
+	}
+
+	dec, err := cbor.DecOptions{}.DecModeWithTags(tags)
+	if err != nil {
+		panic(err)
+	}
+
+	return enc, dec
+}
@@ -0,0 +1,105 @@
+package maya
+
+import (
+	"context"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+
+	original := loadImage(t, "../../sample/original.png")
+	final := loadImage(t, "../../sample/cropped2.png")
+	params := transform.Params{"width-start-new": "2", "height-start-new": "2"}
+
+	prover, err := NewProver(transform.BackendGroth16, nil)
+	require.NoError(t, err)
+
+	proof, err := prover.Prove(ctx, "crop", original, final, params, nil)
+	require.NoError(t, err)
+
+	manifest := *NewManifest(proof, final, nil)
+
+	bundle, err := NewBundle(manifest, proof.Proof, proof.VerifyingKey, true)
+	require.NoError(t, err)
+
+	b, err := bundle.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xda, 'm', 'a', 'y', 'a'}, b[:5])
+
+	got, err := UnmarshalBundle(b)
+	require.NoError(t, err)
+	require.Equal(t, bundle, got)
+	require.Equal(t, params["width-start-new"], got.PublicInputs.Params["width-start-new"])
+
+	opened, err := got.Open(nil, final)
+	require.NoError(t, err)
+	require.Equal(t, proof.OriginalHash, opened.OriginalHash)
+	require.Equal(t, params["width-start-new"], opened.Params["width-start-new"])
+
+	err = NewVerifier(nil).Verify(ctx, opened, final, opened.OriginalHash, nil)
+	require.NoError(t, err)
+
+	t.Run("verifying key hash", func(t *testing.T) {
+		bundle, err := NewBundle(manifest, proof.Proof, nil, false)
+		require.NoError(t, err)
+		require.Nil(t, bundle.VerifyingKey)
+
+		_, err = bundle.Open(nil, final)
+		require.ErrorContains(t, err, "does not include the verifying key")
+
+		_, err = bundle.Open(proof.VerifyingKey, final)
+		require.NoError(t, err)
+
+		_, err = bundle.Open(append([]byte{0}, proof.VerifyingKey...), final)
+		require.ErrorContains(t, err, "verifying key does not match the manifest")
+
+		unpinned := manifest
+		unpinned.VerifyingKeyHash = ""
+		_, err = NewBundle(unpinned, proof.Proof, nil, false)
+		require.ErrorContains(t, err, "requires its hash")
+	})
+
+	t.Run("tampered public inputs", func(t *testing.T) {
+		tampered := *bundle
+		tampered.PublicInputs.Final = transform.Shape{Width: 1, Height: 1}
+
+		_, err := tampered.Open(nil, final)
+		require.ErrorContains(t, err, "public inputs do not match the manifest")
+
+		b, err := tampered.Marshal()
+		require.NoError(t, err)
+
+		_, err = UnmarshalBundle(b)
+		require.ErrorContains(t, err, "public inputs do not match the manifest")
+
+		tampered = *bundle
+		tampered.PublicInputs.Params = transform.Params{"width-start-new": "3"}
+
+		_, err = tampered.Open(nil, final)
+		require.ErrorContains(t, err, "public inputs do not match the manifest")
+	})
+
+	t.Run("final image mismatch", func(t *testing.T) {
+		_, err := bundle.Open(nil, original)
+		require.ErrorContains(t, err, "does not match the public inputs")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := UnmarshalBundle(b[5:])
+		require.ErrorContains(t, err, "invalid bundle")
+
+		_, err = UnmarshalBundle([]byte("maya"))
+		require.ErrorContains(t, err, "invalid bundle")
+
+		other := *bundle
+		other.Version = BundleVersion + 1
+		b, err := other.Marshal()
+		require.NoError(t, err)
+
+		_, err = UnmarshalBundle(b)
+		require.ErrorContains(t, err, "unsupported bundle version")
+	})
+}
@@ -0,0 +1,195 @@
+package maya
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark/constraint"
+	"io"
+	"time"
+)
+
+// Ceremony is the transcript of a multi-party groth16 setup ceremony of a transformation circuit. It records the
+// circuit and the hashes of the phase 1 parameters and of every phase 2 contribution, so anyone can audit it.
+type Ceremony struct {
+	// Transformation is the name of the transformation, see transform.Get.
+	Transformation string `json:"transformation"`
+	// Params are the transformation parameters, including defaults.
+	Params transform.Params `json:"params"`
+	// Original and Final are the shapes of the original and final images.
+	Original transform.Shape `json:"original"`
+	Final    transform.Shape `json:"final"`
+	// Signed is true if the circuit proves the original image hash is signed.
+	Signed bool `json:"signed"`
+	// Phase1Hash is the hex encoded SHA-256 hash of the phase 1 parameters returned by InitCeremony.
+	Phase1Hash string `json:"phase1_hash"`
+	// Contributions are the phase 2 contributions, starting with the initial parameters.
+	Contributions []Contribution `json:"contributions"`
+}
+
+// Contribution is a phase 2 contribution to a ceremony.
+type Contribution struct {
+	// Name identifies the participant, it is informational only.
+	Name string `json:"name"`
+	// Hash is the hex encoded SHA-256 hash of the phase 2 parameters after the contribution.
+	Hash string `json:"hash"`
+	// Time is when the contribution was made, it is informational only.
+	Time time.Time `json:"time"`
+}
+
+// InitCeremony starts the groth16 setup ceremony of the named transformation circuit for the provided image shapes
+// and parameters. If final is the zero shape, it is derived from original.
+// The final image has an alpha channel and 16-bit channels if the original image has them, see Prover.Prove. It reads
+// the phase 1 parameters from a powers of tau file, see transform.ReadPhase1, and returns the transcript, the phase 1
+// parameters truncated to the circuit and the initial phase 2 parameters. Anyone can reproduce the outputs from the same inputs.
+func InitCeremony(ctx context.Context, transformation string, original, final transform.Shape, params transform.Params, signed bool, phase1 io.Reader) (*Ceremony, []byte, []byte, error) {
+	t, err := transform.Get(transformation)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	params = params.WithDefaults(t)
+	if final == (transform.Shape{}) {
+		final, err = transform.FinalShape(t, original, params)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	final.Alpha, final.Depth16 = original.Alpha, original.Depth16
+
+	c := &Ceremony{
+		Transformation: t.Name(),
+		Params:         params,
+		Original:       original,
+		Final:          final,
+		Signed:         signed,
+	}
+
+	cs, err := c.compile(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var p1, p2 []byte
+	err = run(ctx, func() error {
+		var err error
+		if p1, err = transform.ReadPhase1(phase1, cs); err != nil {
+			return err
+		}
+
+		p2, err = transform.InitPhase2(cs, p1)
+		return err
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	c.Phase1Hash = hash(p1)
+	c.Contributions = []Contribution{{Name: "init", Hash: hash(p2), Time: time.Now().UTC()}}
+
+	return c, p1, p2, nil
+}
+
+// Contribute returns the phase 2 parameters with a random contribution to the previous parameters, which must be
+// the latest of the transcript. The contribution is recorded in the transcript under the participant name.
+func (c *Ceremony) Contribute(ctx context.Context, previous []byte, name string) ([]byte, error) {
+	if len(c.Contributions) == 0 {
+		return nil, errors.New("ceremony is not initialised")
+	} else if hash(previous) != c.Contributions[len(c.Contributions)-1].Hash {
+		return nil, errors.New("previous phase 2 parameters are not the latest contribution")
+	}
+
+	var resp []byte
+	err := run(ctx, func() error {
+		var err error
+		resp, err = transform.ContributePhase2(previous)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.Contributions = append(c.Contributions, Contribution{Name: name, Hash: hash(resp), Time: time.Now().UTC()})
+
+	return resp, nil
+}
+
+// Verify verifies that the phase 1 and phase 2 parameters match the transcript and that every contribution is
+// based on the previous one, starting with the initial parameters of the circuit.
+func (c *Ceremony) Verify(ctx context.Context, phase1 []byte, phase2 [][]byte) error {
+	cs, err := c.check(ctx, phase1, phase2)
+	if err != nil {
+		return err
+	}
+
+	return run(ctx, func() error {
+		return transform.VerifyPhase2(cs, phase1, phase2)
+	})
+}
+
+// Finalize verifies the ceremony, see Verify, and returns the proving and verifying keys of the circuit.
+// The ceremony requires at least one contribution.
+func (c *Ceremony) Finalize(ctx context.Context, phase1 []byte, phase2 [][]byte) (*Keys, error) {
+	t0 := time.Now()
+	cs, err := c.check(ctx, phase1, phase2)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Keys{
+		Transformation: c.Transformation,
+		Params:         c.Params,
+		Backend:        transform.BackendGroth16,
+		Original:       c.Original,
+		Final:          c.Final,
+		Signed:         c.Signed,
+	}
+
+	err = run(ctx, func() error {
+		var err error
+		resp.ProvingKey, resp.VerifyingKey, err = transform.ExtractKeys(cs, phase1, phase2)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.SetupTime = time.Since(t0)
+
+	return resp, nil
+}
+
+// check returns the compiled circuit of the ceremony if the parameters match the transcript hashes.
+func (c *Ceremony) check(ctx context.Context, phase1 []byte, phase2 [][]byte) (constraint.ConstraintSystem, error) {
+	if hash(phase1) != c.Phase1Hash {
+		return nil, errors.New("phase 1 parameters do not match the transcript")
+	} else if len(phase2) != len(c.Contributions) {
+		return nil, fmt.Errorf("transcript has %d contributions, got %d", len(c.Contributions), len(phase2))
+	}
+
+	for i := range phase2 {
+		if hash(phase2[i]) != c.Contributions[i].Hash {
+			return nil, fmt.Errorf("contribution %d does not match the transcript", i)
+		}
+	}
+
+	return c.compile(ctx)
+}
+
+// compile returns the groth16 constraint system of the ceremony circuit.
+func (c *Ceremony) compile(ctx context.Context) (constraint.ConstraintSystem, error) {
+	t, err := transform.Get(c.Transformation)
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(ctx, transform.BackendGroth16, t, c.Original, c.Final, c.Params, c.Signed)
+}
+
+// hash returns the hex encoded SHA-256 hash of the data.
+func hash(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
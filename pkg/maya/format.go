@@ -0,0 +1,147 @@
+package maya
+
+import (
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+	"golang.org/x/image/webp"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// Supported image formats, see DecodeImageFormat.
+const (
+	FormatPNG  = "png"
+	FormatJPEG = "jpeg"
+	FormatGIF  = "gif"
+	FormatBMP  = "bmp"
+	FormatTIFF = "tiff"
+	FormatWebP = "webp"
+)
+
+// Formats are the supported image formats. Importing the package registers their decoders with image.Decode.
+var Formats = []string{FormatPNG, FormatJPEG, FormatGIF, FormatBMP, FormatTIFF, FormatWebP}
+
+// decoders are the decoders of the supported image formats.
+var decoders = map[string]func(io.Reader) (image.Image, error){
+	FormatPNG:  png.Decode,
+	FormatJPEG: jpeg.Decode,
+	FormatGIF:  gif.Decode,
+	FormatBMP:  bmp.Decode,
+	FormatTIFF: tiff.Decode,
+	FormatWebP: webp.Decode,
+}
+
+// formatAliases are alternative names of the supported image formats, e.g. their common file extensions.
+var formatAliases = map[string]string{
+	"jpg": FormatJPEG,
+	"tif": FormatTIFF,
+}
+
+// ParseFormat returns the supported image format of the case-insensitive name or file extension, e.g. "JPG".
+func ParseFormat(name string) (string, error) {
+	format := strings.ToLower(name)
+	if alias, ok := formatAliases[format]; ok {
+		format = alias
+	}
+
+	if _, ok := decoders[format]; !ok {
+		return "", fmt.Errorf("unsupported image format %q, supported: %s", name, strings.Join(Formats, ", "))
+	}
+
+	return format, nil
+}
+
+// DecodeImage decodes an image in any supported format, detected from its content.
+func DecodeImage(r io.Reader) (image.Image, error) {
+	return DecodeImageFormat(r, "")
+}
+
+// DecodeImageFormat decodes an image in the provided format, see ParseFormat. If the format is empty, it is detected
+// from the image content.
+func DecodeImageFormat(r io.Reader, format string) (image.Image, error) {
+	if format == "" {
+		img, _, err := image.Decode(r)
+		if errors.Is(err, image.ErrFormat) {
+			return nil, fmt.Errorf("unknown image format, supported: %s", strings.Join(Formats, ", "))
+		} else if err != nil {
+			return nil, err
+		}
+
+		return img, nil
+	}
+
+	format, err := ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := decoders[format](r)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s image, %w", format, err)
+	}
+
+	return img, nil
+}
+
+// ConvertBitDepth returns the image with channel values of the bit depth, 8 or 16, see transform.BitDepth. Images are
+// converted to non-premultiplied RGBA images of the bit depth, or returned unchanged if the bit depth is 0 or matches.
+func ConvertBitDepth(img image.Image, bitDepth int) (image.Image, error) {
+	if bitDepth != 0 && bitDepth != 8 && bitDepth != 16 {
+		return nil, fmt.Errorf("unsupported bit depth %d, supported: 8 and 16", bitDepth)
+	}
+
+	if bitDepth == 0 || bitDepth == transform.BitDepth(img) {
+		return img, nil
+	}
+
+	// Pixels are converted one by one, as drawing goes through premultiplied colors and loses the colors of
+	// transparent pixels.
+	bounds := img.Bounds()
+	if bitDepth == 16 {
+		dst := image.NewNRGBA64(bounds)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				dst.SetNRGBA64(x, y, toNRGBA64(img.At(x, y)))
+			}
+		}
+
+		return dst, nil
+	}
+
+	dst := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dst.SetNRGBA(x, y, toNRGBA(img.At(x, y)))
+		}
+	}
+
+	return dst, nil
+}
+
+// toNRGBA64 returns the 16-bit non-premultiplied color. 8-bit non-premultiplied colors are scaled directly, as
+// color.NRGBA64Model converts them through premultiplied colors.
+func toNRGBA64(c color.Color) color.NRGBA64 {
+	if n, ok := c.(color.NRGBA); ok {
+		return color.NRGBA64{R: uint16(n.R) * 0x101, G: uint16(n.G) * 0x101, B: uint16(n.B) * 0x101, A: uint16(n.A) * 0x101}
+	}
+
+	return color.NRGBA64Model.Convert(c).(color.NRGBA64)
+}
+
+// toNRGBA returns the 8-bit non-premultiplied color. 16-bit non-premultiplied colors are shifted directly, as
+// color.NRGBAModel converts them through premultiplied colors.
+func toNRGBA(c color.Color) color.NRGBA {
+	if n, ok := c.(color.NRGBA64); ok {
+		return color.NRGBA{R: uint8(n.R >> 8), G: uint8(n.G >> 8), B: uint8(n.B >> 8), A: uint8(n.A >> 8)}
+	}
+
+	return color.NRGBAModel.Convert(c).(color.NRGBA)
+}
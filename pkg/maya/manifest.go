@@ -0,0 +1,160 @@
+package maya
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"image"
+	"runtime/debug"
+)
+
+// ManifestVersion is the version of the manifest format.
+const ManifestVersion = 1
+
+// CurveBN254 is the curve of all proofs.
+const CurveBN254 = "bn254"
+
+// Version is the version of the maya module that generated the proofs, from the build info.
+var Version = version()
+
+// Manifest describes a proof, so it can be verified without knowing how it was generated.
+// The hashes are hex encoded SHA-256 hashes.
+type Manifest struct {
+	// Version is the manifest format version, see ManifestVersion.
+	Version int `json:"version"`
+	// MayaVersion is the version of maya that generated the proof.
+	MayaVersion string `json:"maya_version"`
+	// Transformation, Params, Backend, Original and Final are the fields of the proof, see Proof.
+	Transformation string           `json:"transformation"`
+	Params         transform.Params `json:"params"`
+	Backend        string           `json:"backend"`
+	Curve          string           `json:"curve"`
+	Original       transform.Shape  `json:"original"`
+	Final          transform.Shape  `json:"final"`
+	// OriginalHash is the public hash of the original image, see transform.FormatHash.
+	OriginalHash string `json:"original_hash"`
+	// Signer is the hex encoded public key of the original image signer, if any.
+	Signer string `json:"signer,omitempty"`
+	// FinalImageHash is the hash of the final image pixels, independent of the image encoding, see PixelsHash.
+	FinalImageHash string `json:"final_image_hash"`
+	// ProofHash is the hash of the serialised proof.
+	ProofHash string `json:"proof_hash"`
+	// VerifyingKeyHash is the hash of the serialised verifying key, if known to the prover.
+	VerifyingKeyHash string `json:"verifying_key_hash,omitempty"`
+}
+
+// NewManifest returns the manifest of the proof of the final image. The verifying key is optional if the proof does
+// not include it, e.g. if it was generated with a proving key.
+func NewManifest(proof *Proof, final image.Image, verifyingKey []byte) *Manifest {
+	if verifyingKey == nil {
+		verifyingKey = proof.VerifyingKey
+	}
+
+	resp := &Manifest{
+		Version:        ManifestVersion,
+		MayaVersion:    Version,
+		Transformation: proof.Transformation,
+		Params:         proof.Params,
+		Backend:        proof.Backend,
+		Curve:          CurveBN254,
+		Original:       proof.Original,
+		Final:          proof.Final,
+		OriginalHash:   transform.FormatHash(proof.OriginalHash),
+		FinalImageHash: PixelsHash(transform.FromImage(final)),
+		ProofHash:      hash(proof.Proof),
+	}
+
+	if proof.Signer != nil {
+		resp.Signer = hex.EncodeToString(proof.Signer.Bytes())
+	}
+
+	if verifyingKey != nil {
+		resp.VerifyingKeyHash = hash(verifyingKey)
+	}
+
+	return resp
+}
+
+// Proof returns the proof described by the manifest after checking the hashes of the serialised proof, the
+// verifying key and the final image. The verifying key hash is only checked if the manifest includes it.
+func (m *Manifest) Proof(proof, verifyingKey []byte, final image.Image) (*Proof, error) {
+	switch {
+	case m.Version != ManifestVersion:
+		return nil, fmt.Errorf("unsupported manifest version %d", m.Version)
+	case m.Curve != CurveBN254:
+		return nil, fmt.Errorf("unsupported curve, %s", m.Curve)
+	case hash(proof) != m.ProofHash:
+		return nil, errors.New("proof does not match the manifest")
+	case m.VerifyingKeyHash != "" && hash(verifyingKey) != m.VerifyingKeyHash:
+		return nil, errors.New("verifying key does not match the manifest")
+	case PixelsHash(transform.FromImage(final)) != m.FinalImageHash:
+		return nil, errors.New("final image does not match the manifest")
+	}
+
+	originalHash, err := transform.ParseHash(m.OriginalHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var signer *eddsa.PublicKey
+	if m.Signer != "" {
+		b, err := hex.DecodeString(m.Signer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest signer, %w", err)
+		}
+
+		signer = new(eddsa.PublicKey)
+		if _, err := signer.SetBytes(b); err != nil {
+			return nil, fmt.Errorf("invalid manifest signer, %w", err)
+		}
+	}
+
+	return &Proof{
+		Transformation: m.Transformation,
+		Params:         m.Params,
+		Backend:        m.Backend,
+		OriginalHash:   originalHash,
+		Signer:         signer,
+		Original:       m.Original,
+		Final:          m.Final,
+		Proof:          proof,
+		VerifyingKey:   verifyingKey,
+	}, nil
+}
+
+// PixelsHash returns the hex encoded SHA-256 hash of the dimensions and channel values of the pixels. The channel values
+// are written as bytes, or big-endian 16-bit integers if the pixels have 16-bit channels.
+func PixelsHash(pixels transform.Pixels) string {
+	h := sha256.New()
+
+	shape := pixels.Shape()
+	_ = binary.Write(h, binary.BigEndian, [2]uint32{uint32(shape.Width), uint32(shape.Height)})
+	for _, row := range pixels.Values {
+		for _, pixel := range row {
+			if shape.Depth16 {
+				_ = binary.Write(h, binary.BigEndian, pixel)
+				continue
+			}
+
+			for _, v := range pixel {
+				h.Write([]byte{uint8(v)})
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// version returns the version of the maya module from the build info.
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "(devel)"
+	}
+
+	return info.Main.Version
+}
@@ -0,0 +1,56 @@
+package maya
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	ctx := context.Background()
+
+	original := loadImage(t, "../../sample/original.png")
+	final := loadImage(t, "../../sample/cropped2.png")
+	params := transform.Params{"width-start-new": "2", "height-start-new": "2"}
+
+	prover, err := NewProver(transform.BackendGroth16, nil)
+	require.NoError(t, err)
+
+	proof, err := prover.Prove(ctx, "crop", original, final, params, nil)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(NewManifest(proof, final, nil))
+	require.NoError(t, err)
+
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(b, &manifest))
+	require.Equal(t, ManifestVersion, manifest.Version)
+	require.Equal(t, CurveBN254, manifest.Curve)
+	require.Equal(t, "crop", manifest.Transformation)
+	require.Equal(t, "2", manifest.Params["width-start-new"])
+	require.NotEmpty(t, manifest.VerifyingKeyHash)
+
+	got, err := manifest.Proof(proof.Proof, proof.VerifyingKey, final)
+	require.NoError(t, err)
+	require.Equal(t, proof.OriginalHash, got.OriginalHash)
+	require.Equal(t, proof.Final, got.Final)
+
+	err = NewVerifier(got.VerifyingKey).Verify(ctx, got, final, got.OriginalHash, nil)
+	require.NoError(t, err)
+
+	// The hashes bind the manifest to the proof, verifying key and final image.
+	_, err = manifest.Proof(append([]byte{0}, proof.Proof...), proof.VerifyingKey, final)
+	require.ErrorContains(t, err, "proof does not match the manifest")
+
+	_, err = manifest.Proof(proof.Proof, append([]byte{0}, proof.VerifyingKey...), final)
+	require.ErrorContains(t, err, "verifying key does not match the manifest")
+
+	_, err = manifest.Proof(proof.Proof, proof.VerifyingKey, loadImage(t, "../../sample/cropped.png"))
+	require.ErrorContains(t, err, "final image does not match the manifest")
+
+	manifest.Version = ManifestVersion + 1
+	_, err = manifest.Proof(proof.Proof, proof.VerifyingKey, final)
+	require.ErrorContains(t, err, "unsupported manifest version")
+}
@@ -0,0 +1,68 @@
+// Package maya provides the library API to prove and verify image transformations on in-memory images.
+//
+// Unlike the maya CLI, the package never writes to stdout, so it can be embedded in backend services. gnark logs
+// circuit compilation and proving to stdout by default, which callers disable with logger.Disable of
+// github.com/consensys/gnark/logger, or redirect with logger.SetOutput.
+//
+// Functions taking a context stop waiting and return the context error when it is done, but gnark cannot be
+// interrupted, so the compilation or proving step keeps running in the background, holding its CPU and memory,
+// until it finishes. The context bounds the latency of a call, not the work it started.
+package maya
+
+import (
+	"context"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"math/big"
+	"time"
+)
+
+// Proof is a zk proof that the final image is the result of the transformation of an original image.
+type Proof struct {
+	// Transformation is the name of the proven transformation, see transform.Get.
+	Transformation string
+	// Params are the transformation parameters, including defaults.
+	Params transform.Params
+	// Backend is the proving backend, e.g. transform.BackendGroth16.
+	Backend string
+	// OriginalHash is the public hash of the original image, see transform.Pixels.Hash.
+	OriginalHash *big.Int
+	// Signer is the public key of the signer of the original image, or nil if it is not signed.
+	Signer *eddsa.PublicKey
+	// Original and Final are the shapes of the original and final images.
+	Original transform.Shape
+	Final    transform.Shape
+	// Proof and VerifyingKey are the serialised proof and verifying key.
+	Proof        []byte
+	VerifyingKey []byte
+	// CompileTime and ProveTime are the durations of circuit compilation and proving. They are informational only.
+	CompileTime time.Duration
+	ProveTime   time.Duration
+}
+
+// Signature is the signature of the original image hash by a signer, e.g. a capture device.
+type Signature struct {
+	PublicKey *eddsa.PublicKey
+	Signature []byte
+}
+
+// run calls fn and returns its error, or the context error if the context is done first.
+// gnark cannot be interrupted, so fn keeps running in the background after cancellation until it returns, see the
+// package documentation.
+func run(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,247 @@
+package maya
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestProveVerify(t *testing.T) {
+	ctx := context.Background()
+
+	original := loadImage(t, "../../sample/original.png")
+	final := loadImage(t, "../../sample/cropped2.png")
+	params := transform.Params{"width-start-new": "2", "height-start-new": "2"}
+
+	prover, err := NewProver(transform.BackendGroth16, nil)
+	require.NoError(t, err)
+
+	var proof *Proof
+	stdout := captureStdout(t, func() {
+		proof, err = prover.Prove(ctx, "crop", original, final, params, nil)
+	})
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+
+	require.Equal(t, "crop", proof.Transformation)
+	require.Equal(t, transform.BackendGroth16, proof.Backend)
+	require.Equal(t, "2", proof.Params["width-start-new"])
+	require.Equal(t, transform.Shape{Width: 10, Height: 10}, proof.Original)
+	require.Equal(t, transform.Shape{Width: 7, Height: 7}, proof.Final)
+	require.Nil(t, proof.Signer)
+	require.NotEmpty(t, proof.Proof)
+	require.NotEmpty(t, proof.VerifyingKey)
+
+	originalHash, err := transform.FromImage(original).Hash()
+	require.NoError(t, err)
+	require.Equal(t, originalHash, proof.OriginalHash)
+
+	verifier := NewVerifier(nil)
+
+	stdout = captureStdout(t, func() {
+		err = verifier.Verify(ctx, proof, final, originalHash, nil)
+	})
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+
+	// The proof must not verify for a different original image.
+	otherHash, err := transform.FromImage(loadImage(t, "../../sample/brightened.png")).Hash()
+	require.NoError(t, err)
+
+	err = verifier.Verify(ctx, proof, final, otherHash, nil)
+	require.Error(t, err)
+
+	// The proof must not verify for a different final image.
+	err = verifier.Verify(ctx, proof, loadImage(t, "../../sample/cropped.png"), originalHash, nil)
+	require.ErrorContains(t, err, "does not match proof")
+}
+
+func TestProveVerifyReader(t *testing.T) {
+	ctx := context.Background()
+
+	prover, err := NewProver(transform.BackendPlonk, nil)
+	require.NoError(t, err)
+
+	proof, err := prover.ProveReader(ctx, "flip-horizontal", openFile(t, "../../sample/original.png"), openFile(t, "../../sample/flipped_horizontal.png"), nil, nil)
+	require.NoError(t, err)
+
+	err = NewVerifier(nil).VerifyReader(ctx, proof, openFile(t, "../../sample/flipped_horizontal.png"), proof.OriginalHash, nil)
+	require.NoError(t, err)
+
+	_, err = prover.ProveReader(ctx, "flip-horizontal", openFile(t, "../../book/logo.png"), strings.NewReader(""), nil, nil)
+	require.ErrorContains(t, err, "decode final image")
+}
+
+func TestProveSigned(t *testing.T) {
+	ctx := context.Background()
+
+	original := loadImage(t, "../../sample/original.png")
+	final := loadImage(t, "../../sample/rotated90.png")
+
+	originalHash, err := transform.FromImage(original).Hash()
+	require.NoError(t, err)
+
+	privKey, err := eddsa.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sig, err := transform.SignHash(privKey, originalHash)
+	require.NoError(t, err)
+
+	prover, err := NewProver(transform.BackendGroth16, nil)
+	require.NoError(t, err)
+
+	proof, err := prover.Prove(ctx, "rotate90", original, final, nil, &Signature{PublicKey: &privKey.PublicKey, Signature: sig})
+	require.NoError(t, err)
+	require.Equal(t, &privKey.PublicKey, proof.Signer)
+
+	verifier := NewVerifier(nil)
+
+	err = verifier.Verify(ctx, proof, final, originalHash, &privKey.PublicKey)
+	require.NoError(t, err)
+
+	// The proof must not verify without the signer.
+	err = verifier.Verify(ctx, proof, final, originalHash, nil)
+	require.Error(t, err)
+}
+
+func TestProveVerifyDepth16(t *testing.T) {
+	ctx := context.Background()
+
+	// The low bits of the 16-bit original image are lost at 8 bits.
+	original8, err := ConvertBitDepth(loadImage(t, "../../sample/original.png"), 8)
+	require.NoError(t, err)
+
+	original, err := ConvertBitDepth(original8, 16)
+	require.NoError(t, err)
+	require.Equal(t, 16, transform.BitDepth(original))
+
+	// The pixels are big-endian 16-bit R, G, B and A values, the alpha channel stays opaque.
+	img := original.(*image.NRGBA64)
+	for i := 0; i < len(img.Pix); i += 8 {
+		img.Pix[i+1], img.Pix[i+3], img.Pix[i+5] = byte(i), byte(i+1), byte(i+2)
+	}
+
+	flip, err := transform.Get("flip-vertical")
+	require.NoError(t, err)
+
+	final, err := flip.Apply(transform.FromImage(original), nil)
+	require.NoError(t, err)
+
+	prover, err := NewProver(transform.BackendGroth16, nil)
+	require.NoError(t, err)
+
+	proof, err := prover.Prove(ctx, "flip-vertical", original, final.Image(), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, transform.Shape{Width: 10, Height: 10, Depth16: true}, proof.Original)
+	require.Equal(t, transform.Shape{Width: 10, Height: 10, Depth16: true}, proof.Final)
+
+	verifier := NewVerifier(nil)
+
+	err = verifier.Verify(ctx, proof, final.Image(), proof.OriginalHash, nil)
+	require.NoError(t, err)
+
+	// The final image converted to 8 bits lost the low bits, so it is no longer the transformation of the original.
+	final8, err := ConvertBitDepth(final.Image(), 8)
+	require.NoError(t, err)
+
+	err = verifier.Verify(ctx, proof, final8, proof.OriginalHash, nil)
+	require.Error(t, err)
+
+	// 8-bit final images are proven with 16-bit channels if the original image has them.
+	original16, err := ConvertBitDepth(original8, 16)
+	require.NoError(t, err)
+
+	proof, err = prover.Prove(ctx, "identity", original16, original8, nil, nil)
+	require.NoError(t, err)
+	require.True(t, proof.Final.Depth16)
+
+	err = verifier.Verify(ctx, proof, original8, proof.OriginalHash, nil)
+	require.NoError(t, err)
+
+	_, err = ConvertBitDepth(original, 12)
+	require.ErrorContains(t, err, "unsupported bit depth 12")
+}
+
+func TestConvertBitDepth(t *testing.T) {
+	// Transparent pixels keep their colors, which premultiplied colors would lose.
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 10, G: 20, B: 30})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 255, G: 128, B: 1, A: 3})
+
+	img16, err := ConvertBitDepth(img, 16)
+	require.NoError(t, err)
+	require.Equal(t, color.NRGBA64{R: 10 * 257, G: 20 * 257, B: 30 * 257}, img16.At(0, 0))
+	require.Equal(t, color.NRGBA64{R: 255 * 257, G: 128 * 257, B: 257, A: 3 * 257}, img16.At(1, 0))
+
+	img8, err := ConvertBitDepth(img16, 8)
+	require.NoError(t, err)
+	require.Equal(t, img, img8)
+}
+
+func TestProveErrors(t *testing.T) {
+	_, err := NewProver("unknown", nil)
+	require.ErrorContains(t, err, "invalid backend")
+
+	prover, err := NewProver(transform.BackendGroth16, nil)
+	require.NoError(t, err)
+
+	original := loadImage(t, "../../sample/original.png")
+
+	_, err = prover.Prove(context.Background(), "unknown", original, original, nil, nil)
+	require.ErrorContains(t, err, "unknown transformation")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = prover.Prove(ctx, "rotate180", original, loadImage(t, "../../sample/rotated180.png"), nil, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// loadImage returns the decoded image at the provided path.
+func loadImage(t *testing.T, path string) image.Image {
+	t.Helper()
+
+	img, err := DecodeImage(openFile(t, path))
+	require.NoError(t, err)
+
+	return img
+}
+
+// openFile returns the opened file at the provided path, closed when the test completes.
+func openFile(t *testing.T, path string) io.Reader {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	return f
+}
+
+// captureStdout returns everything written to stdout by fn.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(b)
+}
@@ -0,0 +1,119 @@
+package maya
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+)
+
+// BundleChunk is the type of the private ancillary PNG chunk holding an embedded bundle. The chunk is not safe to
+// copy, so PNG editors drop it when they modify the image. Decoders ignore it, so the pixels are not affected.
+const BundleChunk = "maYA"
+
+// pngSignature is the signature of PNG files.
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngChunk is a PNG chunk, see https://www.w3.org/TR/png/#5Chunk-layout.
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// EmbedBundle returns the PNG image with the bundle in a BundleChunk before the end of the image, replacing any
+// previously embedded bundle. The image data is copied unchanged.
+func EmbedBundle(png []byte, bundle *Bundle) ([]byte, error) {
+	chunks, err := readPNGChunks(png)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bundle.Marshal()
+	if err != nil {
+		return nil, err
+	} else if len(data) > math.MaxInt32 {
+		return nil, errors.New("bundle too large to embed")
+	}
+
+	resp := bytes.NewBuffer(append([]byte(nil), pngSignature...))
+	for _, c := range chunks {
+		switch c.typ {
+		case BundleChunk:
+			continue
+		case "IEND":
+			writePNGChunk(resp, pngChunk{typ: BundleChunk, data: data})
+		}
+
+		writePNGChunk(resp, c)
+	}
+
+	return resp.Bytes(), nil
+}
+
+// CheckPNG returns an error if the image is not a valid PNG, which EmbedBundle requires.
+func CheckPNG(png []byte) error {
+	_, err := readPNGChunks(png)
+	return err
+}
+
+// ExtractBundle returns the bundle embedded in the PNG image by EmbedBundle.
+func ExtractBundle(png []byte) (*Bundle, error) {
+	chunks, err := readPNGChunks(png)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range chunks {
+		if c.typ == BundleChunk {
+			return UnmarshalBundle(c.data)
+		}
+	}
+
+	return nil, errors.New("image does not include an embedded bundle")
+}
+
+// readPNGChunks returns the chunks of the PNG image up to and including IEND, after checking their CRCs.
+func readPNGChunks(png []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(png, pngSignature) {
+		return nil, errors.New("not a PNG image")
+	}
+
+	var resp []pngChunk
+	for rest := png[len(pngSignature):]; ; {
+		if len(rest) < 12 {
+			return nil, errors.New("truncated PNG image")
+		}
+
+		length := binary.BigEndian.Uint32(rest)
+		if length > math.MaxInt32 || uint64(len(rest)) < 12+uint64(length) {
+			return nil, errors.New("truncated PNG image")
+		}
+
+		typAndData := rest[4 : 8+length]
+		if crc32.ChecksumIEEE(typAndData) != binary.BigEndian.Uint32(rest[8+length:]) {
+			return nil, fmt.Errorf("invalid PNG chunk %q checksum", typAndData[:4])
+		}
+
+		c := pngChunk{typ: string(typAndData[:4]), data: typAndData[4:]}
+		resp = append(resp, c)
+		rest = rest[12+length:]
+
+		if c.typ == "IEND" {
+			return resp, nil
+		}
+	}
+}
+
+// writePNGChunk writes the chunk with its length and CRC.
+func writePNGChunk(buf *bytes.Buffer, c pngChunk) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(c.data)))
+	buf.WriteString(c.typ)
+	buf.Write(c.data)
+
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(c.typ))
+	crc.Write(c.data)
+	_ = binary.Write(buf, binary.BigEndian, crc.Sum32())
+}
@@ -0,0 +1,65 @@
+package maya
+
+import (
+	"bytes"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"os"
+	"testing"
+)
+
+func TestEmbedBundle(t *testing.T) {
+	png, err := os.ReadFile("../../sample/cropped2.png")
+	require.NoError(t, err)
+
+	proof, vk := []byte("proof"), []byte("verifying key")
+	manifest := Manifest{
+		Version:          ManifestVersion,
+		Curve:            CurveBN254,
+		Transformation:   "crop",
+		OriginalHash:     transform.FormatHash(big.NewInt(1)),
+		ProofHash:        hash(proof),
+		VerifyingKeyHash: hash(vk),
+	}
+
+	bundle, err := NewBundle(manifest, proof, nil, false)
+	require.NoError(t, err)
+
+	embedded, err := EmbedBundle(png, bundle)
+	require.NoError(t, err)
+
+	got, err := ExtractBundle(embedded)
+	require.NoError(t, err)
+	require.Equal(t, bundle, got)
+
+	// The embedded bundle does not affect the pixels.
+	want := transform.FromImage(loadImage(t, "../../sample/cropped2.png"))
+	img, err := DecodeImage(bytes.NewReader(embedded))
+	require.NoError(t, err)
+	require.Equal(t, want, transform.FromImage(img))
+
+	// Embedding again replaces the bundle.
+	bundle.Manifest.Transformation = "crop"
+	embedded, err = EmbedBundle(embedded, bundle)
+	require.NoError(t, err)
+	require.Equal(t, 1, bytes.Count(embedded, []byte(BundleChunk)))
+
+	got, err = ExtractBundle(embedded)
+	require.NoError(t, err)
+	require.Equal(t, "crop", got.Manifest.Transformation)
+
+	_, err = ExtractBundle(png)
+	require.ErrorContains(t, err, "does not include an embedded bundle")
+
+	i := bytes.Index(embedded, []byte(BundleChunk))
+	embedded[i+4] ^= 1
+	_, err = ExtractBundle(embedded)
+	require.ErrorContains(t, err, "invalid PNG chunk \"maYA\" checksum")
+
+	_, err = ExtractBundle(embedded[:i])
+	require.ErrorContains(t, err, "truncated PNG image")
+
+	_, err = EmbedBundle([]byte("GIF89a"), bundle)
+	require.ErrorContains(t, err, "not a PNG image")
+}
@@ -0,0 +1,150 @@
+package maya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/constraint"
+	"image"
+	"io"
+	"time"
+)
+
+// Prover proves image transformations with a proving backend.
+type Prover struct {
+	backend    string
+	provingKey []byte
+}
+
+// NewProver returns a new prover for the proving backend, see transform.BackendGroth16 and transform.BackendPlonk.
+// The proving key is generated by Setup for the transformation circuit to prove. If it is nil, the prover runs an
+// insecure single-use setup for every proof, and the resulting proofs include the matching verifying key.
+func NewProver(backend string, provingKey []byte) (*Prover, error) {
+	if err := checkBackend(backend); err != nil {
+		return nil, err
+	}
+
+	return &Prover{backend: backend, provingKey: provingKey}, nil
+}
+
+// Prove returns the proof that the final image is the result of the named transformation of the original image.
+// The signature is optional, if provided, the proof also attests that the original image hash was signed by the signer.
+// If the original image has an alpha channel, see transform.FromImage, the final image is proven with an alpha channel
+// too, which is opaque if the final image has none. Likewise, if the original image has 16-bit channels, the final
+// image is proven with 16-bit channels too.
+func (p *Prover) Prove(ctx context.Context, transformation string, original, final image.Image, params transform.Params, signature *Signature) (*Proof, error) {
+	t, err := transform.Get(transformation)
+	if err != nil {
+		return nil, err
+	}
+
+	params = params.WithDefaults(t)
+	originalPixels := transform.FromImage(original)
+	finalPixels := transform.FromImage(final)
+	if originalPixels.Shape().Alpha {
+		finalPixels = finalPixels.WithAlpha()
+	}
+	if originalPixels.Depth16 {
+		finalPixels = finalPixels.WithDepth16()
+	}
+	if r, ok := t.(transform.Resolver); ok {
+		params = r.Resolve(originalPixels.Shape(), finalPixels.Shape(), params)
+	}
+
+	originalHash, err := originalPixels.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Proof{
+		Transformation: t.Name(),
+		Params:         params,
+		Backend:        p.backend,
+		OriginalHash:   originalHash,
+		Original:       originalPixels.Shape(),
+		Final:          finalPixels.Shape(),
+	}
+
+	provenance, err := transform.NewProvenance(originalHash, nil, nil)
+	if signature != nil {
+		if signature.PublicKey == nil || signature.Signature == nil {
+			return nil, errors.New("signature requires both public key and signature")
+		}
+
+		resp.Signer = signature.PublicKey
+		provenance, err = transform.NewProvenance(originalHash, signature.PublicKey, signature.Signature)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t0 := time.Now()
+	cs, err := compile(ctx, p.backend, t, resp.Original, resp.Final, params, provenance.Signed())
+	if err != nil {
+		return nil, err
+	}
+	resp.CompileTime = time.Since(t0)
+
+	t0 = time.Now()
+	err = run(ctx, func() error {
+		pk := p.provingKey
+		if pk == nil {
+			var err error
+			pk, resp.VerifyingKey, err = insecureSetup(p.backend, cs)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		resp.Proof, err = transform.Prove(p.backend, cs, pk, t, originalPixels, finalPixels, params, provenance)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.ProveTime = time.Since(t0)
+
+	return resp, nil
+}
+
+// ProveReader is like Prove, but decodes the original and final images from the readers.
+func (p *Prover) ProveReader(ctx context.Context, transformation string, original, final io.Reader, params transform.Params, signature *Signature) (*Proof, error) {
+	originalImg, err := DecodeImage(original)
+	if err != nil {
+		return nil, fmt.Errorf("decode original image, %w", err)
+	}
+
+	finalImg, err := DecodeImage(final)
+	if err != nil {
+		return nil, fmt.Errorf("decode final image, %w", err)
+	}
+
+	return p.Prove(ctx, transformation, originalImg, finalImg, params, signature)
+}
+
+// insecureSetup returns the proving and verifying keys of a single-use setup, with a throwaway SRS for plonk.
+func insecureSetup(backend string, cs constraint.ConstraintSystem) ([]byte, []byte, error) {
+	var srs *kzg.SRS
+	if backend == transform.BackendPlonk {
+		var err error
+		srs, err = transform.NewInsecureSRS(transform.SRSSize(cs))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return transform.Setup(backend, cs, srs)
+}
+
+// checkBackend returns an error if the proving backend is not supported.
+func checkBackend(backend string) error {
+	switch backend {
+	case transform.BackendGroth16, transform.BackendPlonk:
+		return nil
+	default:
+		return fmt.Errorf("invalid backend, %s", backend)
+	}
+}
@@ -0,0 +1,103 @@
+package maya
+
+import (
+	"context"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/constraint"
+	"io"
+	"time"
+)
+
+// Keys are the proving and verifying keys of a transformation circuit for fixed image shapes and parameters.
+type Keys struct {
+	// Transformation is the name of the transformation, see transform.Get.
+	Transformation string
+	// Params are the transformation parameters, including defaults.
+	Params transform.Params
+	// Backend is the proving backend, e.g. transform.BackendGroth16.
+	Backend string
+	// Original and Final are the shapes of the original and final images.
+	Original transform.Shape
+	Final    transform.Shape
+	// Signed is true if the circuit proves the original image hash is signed.
+	Signed bool
+	// ProvingKey and VerifyingKey are the serialised keys.
+	ProvingKey   []byte
+	VerifyingKey []byte
+	// SetupTime is the duration of circuit compilation and setup. It is informational only.
+	SetupTime time.Duration
+}
+
+// Setup returns the proving and verifying keys of the named transformation circuit. The keys only prove and
+// verify images of the provided shapes and parameters. If final is the zero shape, it is derived from original.
+// The final image has an alpha channel and 16-bit channels if the original image has them, see Prover.Prove.
+// The plonk backend requires the KZG SRS of a powers of tau ceremony, of which only the powers required by the
+// circuit are read, see transform.ReadSRS, groth16 ignores it.
+func Setup(ctx context.Context, backend, transformation string, original, final transform.Shape, params transform.Params, signed bool, srs io.Reader) (*Keys, error) {
+	if err := checkBackend(backend); err != nil {
+		return nil, err
+	}
+
+	t, err := transform.Get(transformation)
+	if err != nil {
+		return nil, err
+	}
+
+	params = params.WithDefaults(t)
+	if final == (transform.Shape{}) {
+		final, err = transform.FinalShape(t, original, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	final.Alpha, final.Depth16 = original.Alpha, original.Depth16
+
+	resp := &Keys{
+		Transformation: t.Name(),
+		Params:         params,
+		Backend:        backend,
+		Original:       original,
+		Final:          final,
+		Signed:         signed,
+	}
+
+	t0 := time.Now()
+	cs, err := compile(ctx, backend, t, original, final, params, signed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = run(ctx, func() error {
+		var (
+			kzgSRS *kzg.SRS
+			err    error
+		)
+		if backend == transform.BackendPlonk && srs != nil {
+			if kzgSRS, err = transform.ReadSRS(srs, transform.SRSSize(cs)); err != nil {
+				return err
+			}
+		}
+
+		resp.ProvingKey, resp.VerifyingKey, err = transform.Setup(backend, cs, kzgSRS)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.SetupTime = time.Since(t0)
+
+	return resp, nil
+}
+
+// compile returns the compiled constraint system of the transformation.
+func compile(ctx context.Context, backend string, t transform.Transformation, original, final transform.Shape, params transform.Params, signed bool) (constraint.ConstraintSystem, error) {
+	var cs constraint.ConstraintSystem
+	err := run(ctx, func() error {
+		var err error
+		cs, err = transform.Compile(backend, t, original, final, params, signed)
+		return err
+	})
+
+	return cs, err
+}
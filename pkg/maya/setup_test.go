@@ -0,0 +1,80 @@
+package maya
+
+import (
+	"bytes"
+	"context"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	original := loadImage(t, "../../sample/original.png")
+	final := loadImage(t, "../../sample/rotated270.png")
+
+	// The SRS is ignored by groth16.
+	srs, err := transform.NewInsecureSRS(1<<14 + 3)
+	require.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	_, err = srs.WriteTo(buf)
+	require.NoError(t, err)
+
+	for _, backend := range []string{transform.BackendGroth16, transform.BackendPlonk} {
+		t.Run(backend, func(t *testing.T) {
+			keys, err := Setup(ctx, backend, "rotate270", transform.Shape{Width: 10, Height: 10}, transform.Shape{}, nil, false, bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, transform.Shape{Width: 10, Height: 10}, keys.Final)
+			require.NotEmpty(t, keys.ProvingKey)
+			require.NotEmpty(t, keys.VerifyingKey)
+
+			prover, err := NewProver(backend, keys.ProvingKey)
+			require.NoError(t, err)
+
+			proof, err := prover.Prove(ctx, "rotate270", original, final, nil, nil)
+			require.NoError(t, err)
+			require.Nil(t, proof.VerifyingKey)
+
+			err = NewVerifier(keys.VerifyingKey).Verify(ctx, proof, final, proof.OriginalHash, nil)
+			require.NoError(t, err)
+
+			// The proof must not verify with the keys of another setup.
+			// PLONK setup is deterministic for the same SRS.
+			if backend == transform.BackendGroth16 {
+				other, err := Setup(ctx, backend, "rotate270", transform.Shape{Width: 10, Height: 10}, transform.Shape{}, nil, false, nil)
+				require.NoError(t, err)
+
+				err = NewVerifier(other.VerifyingKey).Verify(ctx, proof, final, proof.OriginalHash, nil)
+				require.Error(t, err)
+			}
+
+			// The proof requires a verifying key.
+			err = NewVerifier(nil).Verify(ctx, proof, final, proof.OriginalHash, nil)
+			require.ErrorContains(t, err, "missing verifying key")
+		})
+	}
+}
+
+func TestSetupMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	keys, err := Setup(ctx, transform.BackendGroth16, "crop", transform.Shape{Width: 10, Height: 10}, transform.Shape{}, transform.Params{"width-new": "5", "height-new": "5"}, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, transform.Shape{Width: 5, Height: 5}, keys.Final)
+
+	prover, err := NewProver(transform.BackendGroth16, keys.ProvingKey)
+	require.NoError(t, err)
+
+	// The proving key only proves crops of the same dimensions.
+	_, err = prover.Prove(ctx, "crop", loadImage(t, "../../sample/original.png"), loadImage(t, "../../sample/cropped2.png"), transform.Params{"width-start-new": "2", "height-start-new": "2"}, nil)
+	require.ErrorContains(t, err, "proving key does not match the circuit")
+
+	_, err = Setup(ctx, transform.BackendGroth16, "crop", transform.Shape{}, transform.Shape{}, nil, false, nil)
+	require.ErrorContains(t, err, "empty image")
+
+	// PLONK keys require the SRS of a powers of tau ceremony.
+	_, err = Setup(ctx, transform.BackendPlonk, "crop", transform.Shape{Width: 10, Height: 10}, transform.Shape{}, nil, false, nil)
+	require.ErrorContains(t, err, "missing kzg srs")
+}
@@ -0,0 +1,91 @@
+package maya
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/0xmayalabs/maya-cli/pkg/transform"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"image"
+	"io"
+	"math/big"
+)
+
+// Verifier verifies proofs of image transformations.
+type Verifier struct {
+	verifyingKey []byte
+}
+
+// NewVerifier returns a new verifier with the verifying key generated by Setup for the transformation circuit to verify.
+// If the verifying key is nil, the verifier trusts the verifying key included in the proof.
+func NewVerifier(verifyingKey []byte) *Verifier {
+	return &Verifier{verifyingKey: verifyingKey}
+}
+
+// Verify verifies the proof that the final image is the result of the proof's transformation of an original image
+// with the expected hash. If signer is not nil, the original image hash must also be signed by the signer.
+// The expected values are provided by the caller, the ones claimed by the proof are ignored. The public parameters of
+// the proof, see transform.Param, are verified as claimed, so callers should check them against their expectations.
+// If the final shape of the proof has an alpha channel or 16-bit channels, the final image is verified with them, see
+// Prover.Prove. Public parameters that default to the original image shape are resolved from the original shape of the
+// proof if it is set, see transform.Resolver.
+func (v *Verifier) Verify(ctx context.Context, proof *Proof, final image.Image, originalHash *big.Int, signer *eddsa.PublicKey) error {
+	if proof == nil {
+		return errors.New("nil proof")
+	}
+
+	if err := checkBackend(proof.Backend); err != nil {
+		return err
+	}
+
+	t, err := transform.Get(proof.Transformation)
+	if err != nil {
+		return err
+	}
+
+	finalPixels := transform.FromImage(final)
+	if proof.Final.Alpha {
+		finalPixels = finalPixels.WithAlpha()
+	}
+	if proof.Final.Depth16 {
+		finalPixels = finalPixels.WithDepth16()
+	}
+	if proof.Final != (transform.Shape{}) && proof.Final != finalPixels.Shape() {
+		return fmt.Errorf("final image %s does not match proof %s", finalPixels.Shape(), proof.Final)
+	}
+
+	provenance, err := transform.NewProvenance(originalHash, signer, nil)
+	if err != nil {
+		return err
+	}
+
+	vk := v.verifyingKey
+	if vk == nil {
+		vk = proof.VerifyingKey
+	}
+
+	if vk == nil {
+		return errors.New("missing verifying key")
+	}
+
+	// Public parameters that default to the original image shape, e.g. the crop dimensions, are resolved from
+	// the shape recorded by the prover. The proof binds them, so a wrong shape fails verification.
+	params := proof.Params.WithDefaults(t)
+	if r, ok := t.(transform.Resolver); ok && proof.Original != (transform.Shape{}) {
+		params = r.Resolve(proof.Original, finalPixels.Shape(), params)
+	}
+
+	return run(ctx, func() error {
+		return transform.Verify(proof.Backend, t, proof.Proof, vk, finalPixels, params, provenance)
+	})
+}
+
+// VerifyReader is like Verify, but decodes the final image from the reader.
+func (v *Verifier) VerifyReader(ctx context.Context, proof *Proof, final io.Reader, originalHash *big.Int, signer *eddsa.PublicKey) error {
+	finalImg, err := DecodeImage(final)
+	if err != nil {
+		return fmt.Errorf("decode final image, %w", err)
+	}
+
+	return v.Verify(ctx, proof, finalImg, originalHash, signer)
+}
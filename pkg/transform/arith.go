@@ -0,0 +1,102 @@
+package transform
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/cmp"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// fixedPointShift is the number of fractional bits of fixed-point factors.
+const fixedPointShift = 8
+
+// toFixedPoint returns the factor in fixed point, rounded to the nearest multiple of 2^-fixedPointShift.
+func toFixedPoint(factor float64) int {
+	return int(math.Round(factor * (1 << fixedPointShift)))
+}
+
+// fixedPointMax returns the maximum fixed-point channel value, which is shifted to the maximum channel value.
+func fixedPointMax(maxValue int) int {
+	return (maxValue+1)<<fixedPointShift - 1
+}
+
+// affine returns the channel value multiplied by scale plus offset, rounded to the nearest integer with halves rounded
+// up, and clamped to [0, maxValue]. The scale and offset are in fixed point. It is the reference implementation of
+// assertAffine.
+func affine(value, scale, offset, maxValue int) uint16 {
+	return toPixel(value*scale+offset, maxValue)
+}
+
+// assertAffine asserts that result is affine of the channel value, scale and offset. The absolute values of the scale
+// and offset must be at most maxScale and maxOffset.
+func assertAffine(api frontend.API, value, result, scale, offset frontend.Variable, maxScale, maxOffset, maxValue int) {
+	assertToPixel(api, api.Add(api.Mul(value, scale), offset), result, maxValue*maxScale+maxOffset, maxValue)
+}
+
+// toPixel returns the fixed-point value rounded to the nearest integer with halves rounded up, and clamped to
+// [0, maxValue]. It is the reference implementation of assertToPixel.
+func toPixel(v, maxValue int) uint16 {
+	v += 1 << (fixedPointShift - 1)
+
+	return uint16(clampInt(v, 0, fixedPointMax(maxValue)) >> fixedPointShift)
+}
+
+// assertToPixel asserts that result is toPixel of the fixed-point value, whose absolute value must be at most bound.
+// The value is clamped before it is shifted, but the division only checks the remainder: the result is unique only
+// if it is range checked to [0, maxValue], as final pixels are by being public inputs supplied by the verifier.
+// Callers with a secret result must range check it themselves.
+func assertToPixel(api frontend.API, v, result frontend.Variable, bound, maxValue int) {
+	v = api.Add(v, 1<<(fixedPointShift-1))
+
+	// The rounded value is at most bound + 2^8 away from 0, and 2 * (maxValue+1) * 2^8 further from fixedPointMax.
+	hi := fixedPointMax(maxValue)
+	v = clamp(api, v, 0, hi, bound+2*(hi+1))
+
+	assertDiv(api, v, result, 1<<fixedPointShift)
+}
+
+// clampInt returns x clamped to [lo, hi]. It is the reference implementation of clamp.
+func clampInt(x, lo, hi int) int {
+	return min(max(x, lo), hi)
+}
+
+// clamp returns x clamped to [lo, hi]. The absolute differences of x to lo and hi must be at most bound,
+// so the comparisons only decompose the differences to the bits of bound.
+func clamp(api frontend.API, x, lo, hi frontend.Variable, bound int) frontend.Variable {
+	comparator := cmp.NewBoundedComparator(api, big.NewInt(int64(bound)), false)
+
+	// max(x, lo) = x + lo - min(x, lo)
+	x = api.Sub(api.Add(x, lo), comparator.Min(x, lo))
+
+	return comparator.Min(x, hi)
+}
+
+// assertDiv asserts that quotient is the non-negative value divided by the positive divisor, rounded down,
+// i.e. that the remainder value - quotient*divisor is in [0, divisor). The quotient must be a pixel value or
+// otherwise range checked by the caller, so it is unique.
+func assertDiv(api frontend.API, value, quotient frontend.Variable, divisor int) {
+	if divisor == 1 {
+		api.AssertIsEqual(value, quotient)
+		return
+	}
+
+	remainder := api.Sub(value, api.Mul(quotient, divisor))
+
+	// Range check the remainder to the bits of divisor-1, then check its upper bound unless the divisor is a power of two.
+	n := bits.Len(uint(divisor - 1))
+	api.ToBinary(remainder, n)
+	if divisor != 1<<n {
+		api.ToBinary(api.Sub(divisor-1, remainder), n)
+	}
+}
+
+// assertDivVariable is like assertDiv, but for a variable divisor in [1, maxDivisor].
+func assertDivVariable(api frontend.API, value, quotient, divisor frontend.Variable, maxDivisor int) {
+	remainder := api.Sub(value, api.Mul(quotient, divisor))
+
+	// Range check the remainder and divisor-1-remainder to the bits of maxDivisor-1, so the remainder is in [0, divisor).
+	n := bits.Len(uint(maxDivisor - 1))
+	api.ToBinary(remainder, n)
+	api.ToBinary(api.Sub(divisor, 1, remainder), n)
+}
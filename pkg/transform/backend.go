@@ -0,0 +1,190 @@
+package transform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"io"
+)
+
+const (
+	BackendGroth16 = "groth16"
+	BackendPlonk   = "plonk" // TODO(dhruv): add plonkfri when its serialisation is supported.
+)
+
+// Compile compiles the circuit of the transformation for the provided image shapes with the proving backend.
+func Compile(backend string, t Transformation, original, final Shape, params Params, signed bool) (constraint.ConstraintSystem, error) {
+	circuit, err := t.Circuit(original, final, params, signed)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case BackendGroth16:
+		return frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	case BackendPlonk:
+		return frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+	default:
+		return nil, fmt.Errorf("invalid backend, %s", backend)
+	}
+}
+
+// Setup returns the serialised proving and verifying keys of the constraint system compiled by Compile.
+// The plonk backend requires a KZG SRS of at least SRSSize powers, see ReadSRS, it is ignored by groth16.
+// The groth16 keys are generated by a single party, use the ceremony, see ExtractKeys, for production keys.
+func Setup(backend string, cs constraint.ConstraintSystem, srs *kzg.SRS) ([]byte, []byte, error) {
+	switch backend {
+	case BackendGroth16:
+		pk, vk, err := groth16.Setup(cs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return marshal(pk, vk)
+	case BackendPlonk:
+		if srs == nil {
+			return nil, nil, errors.New("missing kzg srs, required by the plonk backend")
+		} else if len(srs.Pk.G1) < SRSSize(cs) {
+			return nil, nil, fmt.Errorf("kzg srs has %d powers, the circuit requires %d", len(srs.Pk.G1), SRSSize(cs))
+		}
+
+		pk, vk, err := plonk.Setup(cs, srs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return marshal(pk, vk)
+	default:
+		return nil, nil, fmt.Errorf("invalid backend, %s", backend)
+	}
+}
+
+// Prove returns the serialised proof of the transformation from the original to the final pixels with the
+// serialised proving key. The constraint system and proving key must be generated by Compile and Setup with
+// the same transformation, shapes and parameters.
+func Prove(backend string, cs constraint.ConstraintSystem, pk []byte, t Transformation, original, final Pixels, params Params, provenance Provenance) ([]byte, error) {
+	assignment, err := t.Assignment(original, final, params, provenance)
+	if err != nil {
+		return nil, err
+	}
+
+	wit, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := proveByBackend(backend, cs, pk, wit)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := proof.WriteTo(buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Verify verifies the serialised proof of the transformation resulting in the final pixels,
+// using the serialised verifying key and the public provenance, see NewProvenance.
+func Verify(backend string, t Transformation, proof, vk []byte, final Pixels, params Params, provenance Provenance) error {
+	assignment, err := t.Assignment(Pixels{}, final, params, provenance)
+	if err != nil {
+		return err
+	}
+
+	wit, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		return err
+	}
+
+	return verifyByBackend(backend, proof, vk, wit)
+}
+
+func proveByBackend(backend string, cs constraint.ConstraintSystem, pk []byte, witness witness.Witness) (io.WriterTo, error) {
+	switch backend {
+	case BackendGroth16:
+		grothPk := groth16.NewProvingKey(ecc.BN254)
+		if _, err := grothPk.ReadFrom(bytes.NewReader(pk)); err != nil {
+			return nil, fmt.Errorf("invalid proving key, %w", err)
+		}
+
+		// gnark panics on proving keys of a different circuit.
+		internal, secret, public := cs.GetNbVariables()
+		if pk, ok := grothPk.(*groth16bn254.ProvingKey); !ok || len(pk.InfinityA) != internal+secret+public {
+			return nil, errors.New("proving key does not match the circuit")
+		}
+
+		return groth16.Prove(cs, grothPk, witness)
+	case BackendPlonk:
+		plonkPk := plonk.NewProvingKey(ecc.BN254)
+		if _, err := plonkPk.ReadFrom(bytes.NewReader(pk)); err != nil {
+			return nil, fmt.Errorf("invalid proving key, %w", err)
+		}
+
+		return plonk.Prove(cs, plonkPk, witness)
+	default:
+		return nil, fmt.Errorf("invalid backend, %s", backend)
+	}
+}
+
+func verifyByBackend(backend string, proof, vk []byte, pubWit witness.Witness) error {
+	switch backend {
+	case BackendGroth16:
+		grothProof := groth16.NewProof(ecc.BN254)
+		_, err := grothProof.ReadFrom(bytes.NewBuffer(proof))
+		if err != nil {
+			return err
+		}
+
+		grothVk := groth16.NewVerifyingKey(ecc.BN254)
+		_, err = grothVk.ReadFrom(bytes.NewBuffer(vk))
+		if err != nil {
+			return err
+		}
+
+		return groth16.Verify(grothProof, grothVk, pubWit)
+	case BackendPlonk:
+		plonkProof := plonk.NewProof(ecc.BN254)
+		_, err := plonkProof.ReadFrom(bytes.NewBuffer(proof))
+		if err != nil {
+			return err
+		}
+
+		plonkVk := plonk.NewVerifyingKey(ecc.BN254)
+		_, err = plonkVk.ReadFrom(bytes.NewBuffer(vk))
+		if err != nil {
+			return err
+		}
+
+		return plonk.Verify(plonkProof, plonkVk, pubWit)
+	default:
+		return fmt.Errorf("invalid backend, %s", backend)
+	}
+}
+
+// marshal returns the serialised proof and verifying key.
+func marshal(proof, vk io.WriterTo) ([]byte, []byte, error) {
+	proofBuf := new(bytes.Buffer)
+	if _, err := proof.WriteTo(proofBuf); err != nil {
+		return nil, nil, err
+	}
+
+	vkBuf := new(bytes.Buffer)
+	if _, err := vk.WriteTo(vkBuf); err != nil {
+		return nil, nil, err
+	}
+
+	return proofBuf.Bytes(), vkBuf.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+)
+
+func init() {
+	Register(brighten{})
+}
+
+const (
+	// maxBrighteningFactor is the maximum absolute brightening factor.
+	maxBrighteningFactor = MaxPixelValue
+	// maxBrighteningMultiplier is the maximum brightening multiplier.
+	maxBrighteningMultiplier = 16
+)
+
+// brighten brightens or darkens the original image by multiplying every channel by the brightening multiplier and
+// adding the brightening factor.
+type brighten struct{}
+
+func (brighten) Name() string {
+	return "brighten"
+}
+
+func (brighten) Description() string {
+	return "Brightens or darkens the original image by a brightening factor and multiplier."
+}
+
+func (brighten) Params() []Param {
+	return []Param{
+		{
+			Name:    "brightening-factor",
+			Usage:   "The value added to every channel in [-255, 255], rounded to a multiple of 1/256 and multiplied by 257 for 16-bit images. Negative factors darken the image.",
+			Kind:    ParamFloat,
+			Default: "2",
+			Public:  true,
+		},
+		{
+			Name:    "brightening-multiplier",
+			Usage:   "The value every channel is multiplied by before adding the factor in [0, 16], rounded to a multiple of 1/256. Multipliers below 1 darken the image.",
+			Kind:    ParamFloat,
+			Default: "1",
+			Public:  true,
+		},
+	}
+}
+
+func (b brighten) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The factors are public inputs, so the circuit does not depend on them, but they are checked to fail early.
+	if _, _, err := b.factors(params); err != nil {
+		return nil, err
+	}
+
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &BrightenCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Brightened: final.variables(),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (b brighten) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	factor, multiplier, err := b.factors(params)
+	if err != nil {
+		return nil, err
+	}
+
+	bitDepth := final.Shape().BitDepth()
+
+	return &BrightenCircuit{
+		Provenance:            provenance,
+		Original:              original.variables(),
+		Brightened:            final.variables(),
+		BrighteningFactor:     factor * depthScale(bitDepth),
+		BrighteningMultiplier: multiplier,
+		BitDepth:              bitDepth,
+	}, nil
+}
+
+func (b brighten) Apply(original Pixels, params Params) (Pixels, error) {
+	factor, multiplier, err := b.factors(params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	if err := original.Shape().validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	shape := original.Shape()
+	factor *= depthScale(shape.BitDepth())
+
+	resp := NewPixels(shape)
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			for k := 0; k < 3; k++ {
+				resp.Values[i][j][k] = affine(int(original.Values[i][j][k]), multiplier, factor, shape.MaxValue())
+			}
+			copyAlpha(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// factors returns the fixed-point brightening factor and multiplier, checking their ranges.
+func (brighten) factors(params Params) (int, int, error) {
+	factor, err := params.Float("brightening-factor")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	multiplier, err := params.Float("brightening-multiplier")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if factor < -maxBrighteningFactor || factor > maxBrighteningFactor {
+		return 0, 0, fmt.Errorf("brightening factor %v out of range [-%d, %d]", factor, maxBrighteningFactor, maxBrighteningFactor)
+	}
+
+	if multiplier < 0 || multiplier > maxBrighteningMultiplier {
+		return 0, 0, fmt.Errorf("brightening multiplier %v out of range [0, %d]", multiplier, maxBrighteningMultiplier)
+	}
+
+	return toFixedPoint(factor), toFixedPoint(multiplier), nil
+}
+
+// BrightenCircuit represents the arithmetic circuit to prove brighten transformations.
+// The brightening factor, in channel values of the bit depth, and multiplier are public inputs in fixed point, see affine.
+type BrightenCircuit struct {
+	Provenance            Provenance
+	Original              [][][]frontend.Variable `gnark:",secret"`
+	Brightened            [][][]frontend.Variable `gnark:",public"`
+	BrighteningFactor     frontend.Variable       `gnark:",public"`
+	BrighteningMultiplier frontend.Variable       `gnark:",public"`
+	BitDepth              int
+}
+
+func (c *BrightenCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	// The verifier checks the ranges of the factors, see brighten.factors.
+	maxFactor, maxMultiplier := toFixedPoint(maxBrighteningFactor)*depthScale(c.BitDepth), toFixedPoint(maxBrighteningMultiplier)
+
+	// The pixel values of the brightened image must be the clamped affine transformation of the original pixel values.
+	for i := 0; i < len(c.Original); i++ {
+		for j := 0; j < len(c.Original[0]); j++ {
+			for k := 0; k < 3; k++ {
+				assertAffine(api, c.Original[i][j][k], c.Brightened[i][j][k], c.BrighteningMultiplier, c.BrighteningFactor, maxMultiplier, maxFactor, maxValue(c.BitDepth))
+			}
+			assertAlpha(api, c.Original[i][j], c.Brightened[i][j])
+		}
+	}
+
+	return nil
+}
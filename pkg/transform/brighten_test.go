@@ -0,0 +1,103 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBrighten(t *testing.T) {
+	tr, err := Get("brighten")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 1, 100}, {128, 250, 255}}}}
+
+	tests := []struct {
+		name   string
+		params Params
+		final  Pixels
+	}{
+		{
+			name:   "default",
+			params: Params{},
+			final:  Pixels{Values: [][][]uint16{{{2, 3, 102}, {130, 252, 255}}}},
+		},
+		{
+			name:   "negative factor",
+			params: Params{"brightening-factor": "-2"},
+			final:  Pixels{Values: [][][]uint16{{{0, 0, 98}, {126, 248, 253}}}},
+		},
+		{
+			// Halves are rounded up, also for negative values: 1 - 1.5 = -0.5 -> 0.
+			name:   "fractional factor",
+			params: Params{"brightening-factor": "-1.5"},
+			final:  Pixels{Values: [][][]uint16{{{0, 0, 99}, {127, 249, 254}}}},
+		},
+		{
+			// 1.1 is rounded to 282/256, e.g. 100 -> 110.16 -> 110, 250 -> 275.4 -> 255.
+			name:   "multiplier",
+			params: Params{"brightening-factor": "0", "brightening-multiplier": "1.1"},
+			final:  Pixels{Values: [][][]uint16{{{0, 1, 110}, {141, 255, 255}}}},
+		},
+		{
+			// 0.5 * 255 - 10 = 117.5 -> 118.
+			name:   "darkening multiplier",
+			params: Params{"brightening-factor": "-10", "brightening-multiplier": "0.5"},
+			final:  Pixels{Values: [][][]uint16{{{0, 0, 40}, {54, 115, 118}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := tr.Apply(original, tt.params.WithDefaults(tr))
+			require.NoError(t, err)
+			require.Equal(t, tt.final, final)
+		})
+	}
+
+	for _, params := range []Params{
+		{"brightening-factor": "-256"},
+		{"brightening-factor": "256"},
+		{"brightening-multiplier": "-1"},
+		{"brightening-multiplier": "17"},
+	} {
+		_, err = tr.Apply(original, params.WithDefaults(tr))
+		require.ErrorContains(t, err, "out of range")
+	}
+}
+
+func TestBrightenPublicFactors(t *testing.T) {
+	tr, err := Get("brighten")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 1, 100}, {128, 250, 255}}}}
+	params := Params{"brightening-factor": "-1.5", "brightening-multiplier": "1.1"}.WithDefaults(tr)
+
+	final, err := tr.Apply(original, params)
+	require.NoError(t, err)
+
+	// The circuit does not depend on the factors, so the keys can be reused for any factors.
+	cs, err := Compile(BackendGroth16, tr, original.Shape(), final.Shape(), Params{}.WithDefaults(tr), false)
+	require.NoError(t, err)
+
+	pk, vk, err := Setup(BackendGroth16, cs, nil)
+	require.NoError(t, err)
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	proof, err := Prove(BackendGroth16, cs, pk, tr, original, final, params, provenance)
+	require.NoError(t, err)
+	require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, params, provenance))
+
+	// The verifier provides the factors claimed by the prover.
+	for _, other := range []Params{
+		{"brightening-factor": "-1.5"},
+		{"brightening-factor": "-1", "brightening-multiplier": "1.1"},
+	} {
+		err = Verify(BackendGroth16, tr, proof, vk, final, other.WithDefaults(tr), provenance)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,354 @@
+package transform
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/consensys/gnark/constraint"
+	csbn254 "github.com/consensys/gnark/constraint/bn254"
+	"io"
+	"math/bits"
+	"reflect"
+)
+
+// The groth16 setup ceremony has two phases, see https://eprint.iacr.org/2017/1050.pdf. Phase 1 is the universal
+// powers of tau, e.g. from the perpetual powers of tau ceremony. Phase 2 is specific to the circuit and is updated
+// by the contributions of every participant. The keys are secure if any participant discarded their contribution.
+
+// ReadPhase1 reads the phase 1 parameters of a groth16 ceremony in either the snarkjs powers of tau format (.ptau)
+// or the gnark native format written by mpcsetup.Phase1.WriteTo. It returns the parameters truncated to the size of
+// the constraint system compiled by Compile, serialised in the gnark native format.
+func ReadPhase1(r io.Reader, cs constraint.ConstraintSystem) ([]byte, error) {
+	n := phase1Size(cs)
+
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(len(ptauMagic))
+	if err != nil {
+		return nil, fmt.Errorf("read phase 1, %w", err)
+	}
+
+	phase1 := new(mpcsetup.Phase1)
+	if bytes.Equal(magic, ptauMagic) {
+		phase1, err = readPtauPhase1(br, n)
+	} else {
+		_, err = phase1.ReadFrom(br)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read phase 1, %w", err)
+	}
+
+	params := &phase1.Parameters
+	if len(params.G1.Tau) < 2*n-1 || len(params.G2.Tau) < n || len(params.G1.AlphaTau) < n || len(params.G1.BetaTau) < n {
+		return nil, fmt.Errorf("phase 1 has %d powers, the circuit requires %d", len(params.G2.Tau), n)
+	}
+
+	params.G1.Tau = params.G1.Tau[:2*n-1]
+	params.G2.Tau = params.G2.Tau[:n]
+	params.G1.AlphaTau = params.G1.AlphaTau[:n]
+	params.G1.BetaTau = params.G1.BetaTau[:n]
+
+	if err := checkPhase1(phase1); err != nil {
+		return nil, err
+	}
+
+	// The public keys and hash of the phase 1 contributions are not required by phase 2.
+	phase1.PublicKeys = mpcsetup.Phase1{}.PublicKeys
+	phase1.Hash = nil
+
+	buf := new(bytes.Buffer)
+	if _, err := phase1.WriteTo(buf); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(buf.Bytes())
+	buf.Write(hash[:])
+
+	return buf.Bytes(), nil
+}
+
+// InitPhase2 returns the serialised initial phase 2 parameters of the constraint system compiled by Compile with
+// the groth16 backend, using the phase 1 parameters returned by ReadPhase1. The parameters are deterministic,
+// only the public key of the initial contribution is random.
+func InitPhase2(cs constraint.ConstraintSystem, phase1 []byte) ([]byte, error) {
+	phase2, _, err := initPhase2(cs, phase1)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalPhase2(phase2)
+}
+
+// ContributePhase2 returns the serialised phase 2 parameters updated with a random contribution.
+// The contribution is discarded, so it cannot be used to forge proofs.
+func ContributePhase2(previous []byte) ([]byte, error) {
+	phase2, err := unmarshalPhase2(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	phase2.Contribute()
+
+	return marshalPhase2(phase2)
+}
+
+// VerifyPhase2 verifies the phase 2 parameters of every contribution, starting with the initial parameters of the
+// constraint system and phase 1 parameters, see InitPhase2. Each contribution must be based on the previous one.
+func VerifyPhase2(cs constraint.ConstraintSystem, phase1 []byte, phase2 [][]byte) error {
+	_, err := verifyPhase2(cs, phase1, phase2)
+	return err
+}
+
+// ExtractKeys verifies the phase 2 contributions, see VerifyPhase2, and returns the serialised proving and
+// verifying keys of the constraint system from the last contribution.
+func ExtractKeys(cs constraint.ConstraintSystem, phase1 []byte, phase2 [][]byte) ([]byte, []byte, error) {
+	if len(phase2) < 2 {
+		return nil, nil, errors.New("phase 2 has no contributions")
+	}
+
+	evals, err := verifyPhase2(cs, phase1, phase2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	srs1, err := unmarshalPhase1(phase1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last, err := unmarshalPhase2(phase2[len(phase2)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pk, vk := mpcsetup.ExtractKeys(srs1, last, evals, cs.GetNbConstraints())
+
+	return marshal(&pk, &vk)
+}
+
+// verifyPhase2 verifies the phase 2 contributions and returns the evaluations of the circuit.
+func verifyPhase2(cs constraint.ConstraintSystem, phase1 []byte, phase2 [][]byte) (*mpcsetup.Phase2Evaluations, error) {
+	if len(phase2) == 0 {
+		return nil, errors.New("missing initial phase 2 parameters")
+	}
+
+	initial, evals, err := initPhase2(cs, phase1)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := unmarshalPhase2(phase2[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// The hash of the initial parameters is the challenge of the first contribution.
+	content, sum := phase2[0][:len(phase2[0])-sha256.Size], phase2[0][len(phase2[0])-sha256.Size:]
+	if h := sha256.Sum256(content); !reflect.DeepEqual(prev.Parameters, initial.Parameters) || !bytes.Equal(h[:], sum) {
+		return nil, errors.New("initial phase 2 parameters do not match the circuit and phase 1")
+	}
+
+	for i := 1; i < len(phase2); i++ {
+		next, err := unmarshalPhase2(phase2[i])
+		if err != nil {
+			return nil, fmt.Errorf("contribution %d, %w", i, err)
+		}
+
+		if err := mpcsetup.VerifyPhase2(prev, next); err != nil {
+			return nil, fmt.Errorf("contribution %d, %w", i, err)
+		}
+
+		prev = next
+	}
+
+	return evals, nil
+}
+
+// initPhase2 returns the initial phase 2 parameters and the evaluations of the circuit.
+func initPhase2(cs constraint.ConstraintSystem, phase1 []byte) (*mpcsetup.Phase2, *mpcsetup.Phase2Evaluations, error) {
+	r1cs, ok := cs.(*csbn254.R1CS)
+	if !ok || r1cs.Type != constraint.SystemR1CS {
+		return nil, nil, errors.New("ceremony requires the groth16 backend")
+	}
+
+	// gnark does not generate commitment keys in the ceremony.
+	if len(r1cs.CommitmentInfo.CommitmentIndexes()) > 0 {
+		return nil, nil, errors.New("ceremony does not support circuits with commitments")
+	}
+
+	srs1, err := unmarshalPhase1(phase1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(srs1.Parameters.G1.AlphaTau) != phase1Size(cs) {
+		return nil, nil, errors.New("phase 1 parameters do not match the circuit")
+	}
+
+	phase2, evals := mpcsetup.InitPhase2(r1cs, srs1)
+
+	return &phase2, &evals, nil
+}
+
+// phase1Size returns the number of powers of phase 1, the size of the groth16 domain of the constraint system.
+func phase1Size(cs constraint.ConstraintSystem) int {
+	return int(ecc.NextPowerOfTwo(uint64(cs.GetNbConstraints())))
+}
+
+// checkPhase1 returns an error if the phase 1 parameters are not consistent powers of the same τ, α and β.
+func checkPhase1(phase1 *mpcsetup.Phase1) error {
+	_, _, g1, g2 := bn254.Generators()
+	params := phase1.Parameters
+
+	switch {
+	case len(params.G2.Tau) < 2:
+		return errors.New("phase 1 has less than 2 powers")
+	case !params.G1.Tau[0].Equal(&g1) || !params.G2.Tau[0].Equal(&g2):
+		return errors.New("phase 1 does not start with the generators")
+	}
+
+	// Secrets of zero or one, e.g. of phase 1 without contributions, are known to everyone.
+	for _, p := range []bn254.G1Affine{params.G1.Tau[1], params.G1.AlphaTau[0], params.G1.BetaTau[0]} {
+		if p.IsInfinity() || p.Equal(&g1) {
+			return errors.New("phase 1 has an invalid secret")
+		}
+	}
+
+	for _, powers := range []struct {
+		name   string
+		points []bn254.G1Affine
+	}{
+		{"tau", params.G1.Tau},
+		{"alpha", params.G1.AlphaTau},
+		{"beta", params.G1.BetaTau},
+	} {
+		for i := range powers.points {
+			if !powers.points[i].IsInSubGroup() {
+				return fmt.Errorf("phase 1 %s power %d is not a valid point", powers.name, i)
+			}
+		}
+
+		if err := checkPowers(powers.points, params.G2.Tau[1]); err != nil {
+			return fmt.Errorf("phase 1 %s points are not consecutive powers, %w", powers.name, err)
+		}
+	}
+
+	// The G2 powers must match the G1 powers: e(∑rᵢ[τⁱ]G₁, G₂) = e(G₁, ∑rᵢ[τⁱ]G₂), and β in G2 the one in G1.
+	scalars := make([]fr.Element, len(params.G2.Tau))
+	for i := range scalars {
+		if _, err := scalars[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	var tauG1 bn254.G1Affine
+	if _, err := tauG1.MultiExp(params.G1.Tau[:len(scalars)], scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	var tauG2 bn254.G2Affine
+	if _, err := tauG2.MultiExp(params.G2.Tau, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	var negG1 bn254.G1Affine
+	negG1.Neg(&g1)
+
+	ok, err := bn254.PairingCheck(
+		[]bn254.G1Affine{tauG1, negG1, params.G1.BetaTau[0], negG1},
+		[]bn254.G2Affine{g2, tauG2, g2, params.G2.Beta},
+	)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.New("phase 1 G2 points do not match the G1 points")
+	}
+
+	return nil
+}
+
+// readPtauPhase1 reads the phase 1 parameters of n powers from a snarkjs powers of tau file.
+func readPtauPhase1(r io.Reader, n int) (*mpcsetup.Phase1, error) {
+	phase1 := new(mpcsetup.Phase1)
+	params := &phase1.Parameters
+
+	err := readPtauSections(r, func(power int, section uint32, data io.Reader) error {
+		if n > 1<<power {
+			return fmt.Errorf("ptau of power %d is too small, the circuit requires power %d", power, bits.Len(uint(n))-1)
+		}
+
+		var (
+			err  error
+			beta []bn254.G2Affine
+		)
+		switch section {
+		case ptauSectionTauG1:
+			params.G1.Tau, err = readPtauG1s(data, 2*n-1)
+		case ptauSectionTauG2:
+			params.G2.Tau, err = readPtauG2s(data, n)
+		case ptauSectionAlphaG1:
+			params.G1.AlphaTau, err = readPtauG1s(data, n)
+		case ptauSectionBetaG1:
+			params.G1.BetaTau, err = readPtauG1s(data, n)
+		case ptauSectionBetaG2:
+			beta, err = readPtauG2s(data, 1)
+			if err == nil {
+				params.G2.Beta = beta[0]
+			}
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if params.G2.Beta.IsInfinity() {
+		return nil, errors.New("ptau file is missing the powers of tau")
+	}
+
+	for _, p := range params.G2.Tau {
+		if !p.IsInSubGroup() {
+			return nil, errors.New("ptau tau G2 power is not a valid point")
+		}
+	}
+
+	if !params.G2.Beta.IsInSubGroup() {
+		return nil, errors.New("ptau beta G2 is not a valid point")
+	}
+
+	return phase1, nil
+}
+
+func unmarshalPhase1(b []byte) (*mpcsetup.Phase1, error) {
+	phase1 := new(mpcsetup.Phase1)
+	if _, err := phase1.ReadFrom(bytes.NewReader(b)); err != nil {
+		return nil, fmt.Errorf("invalid phase 1 parameters, %w", err)
+	}
+
+	return phase1, nil
+}
+
+func unmarshalPhase2(b []byte) (*mpcsetup.Phase2, error) {
+	phase2 := new(mpcsetup.Phase2)
+	if _, err := phase2.ReadFrom(bytes.NewReader(b)); err != nil {
+		return nil, fmt.Errorf("invalid phase 2 parameters, %w", err)
+	}
+
+	return phase2, nil
+}
+
+func marshalPhase2(phase2 *mpcsetup.Phase2) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if _, err := phase2.WriteTo(buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,112 @@
+package transform
+
+import (
+	"bytes"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCeremony(t *testing.T) {
+	crop, err := Get("crop")
+	require.NoError(t, err)
+
+	pixels := Pixels{Values: [][][]uint16{
+		{{1, 2, 3}, {4, 5, 6}},
+		{{7, 8, 9}, {10, 11, 12}},
+	}}
+	params := crop.(Resolver).Resolve(pixels.Shape(), pixels.Shape(), Params{}.WithDefaults(crop))
+
+	cs, err := Compile(BackendGroth16, crop, pixels.Shape(), pixels.Shape(), params, false)
+	require.NoError(t, err)
+
+	power := 9
+	require.Equal(t, 1<<power, phase1Size(cs))
+
+	_, ptau := newPtau(t, power)
+	phase1, err := ReadPhase1(bytes.NewReader(ptau), cs)
+	require.NoError(t, err)
+
+	initial, err := InitPhase2(cs, phase1)
+	require.NoError(t, err)
+
+	phase2 := [][]byte{initial}
+	for i := 0; i < 2; i++ {
+		next, err := ContributePhase2(phase2[len(phase2)-1])
+		require.NoError(t, err)
+
+		phase2 = append(phase2, next)
+	}
+
+	require.NoError(t, VerifyPhase2(cs, phase1, phase2))
+
+	pk, vk, err := ExtractKeys(cs, phase1, phase2)
+	require.NoError(t, err)
+
+	hash, err := pixels.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	proof, err := Prove(BackendGroth16, cs, pk, crop, pixels, pixels, params, provenance)
+	require.NoError(t, err)
+	require.NoError(t, Verify(BackendGroth16, crop, proof, vk, pixels, params, provenance))
+
+	t.Run("skipped contribution", func(t *testing.T) {
+		err := VerifyPhase2(cs, phase1, [][]byte{initial, phase2[2]})
+		require.ErrorContains(t, err, "contribution 1")
+	})
+
+	t.Run("no contributions", func(t *testing.T) {
+		_, _, err := ExtractKeys(cs, phase1, [][]byte{initial})
+		require.ErrorContains(t, err, "no contributions")
+	})
+
+	t.Run("other initial parameters", func(t *testing.T) {
+		// Anyone can reproduce the initial parameters.
+		other, err := InitPhase2(cs, phase1)
+		require.NoError(t, err)
+		require.NoError(t, VerifyPhase2(cs, phase1, [][]byte{other}))
+
+		other, err = ContributePhase2(initial)
+		require.NoError(t, err)
+
+		err = VerifyPhase2(cs, phase1, [][]byte{other, phase2[1]})
+		require.ErrorContains(t, err, "initial phase 2 parameters do not match")
+	})
+
+	t.Run("gnark phase 1", func(t *testing.T) {
+		srs1 := mpcsetup.InitPhase1(power)
+
+		// Phase 1 without contributions has known secrets.
+		buf := new(bytes.Buffer)
+		_, err := srs1.WriteTo(buf)
+		require.NoError(t, err)
+
+		_, err = ReadPhase1(buf, cs)
+		require.ErrorContains(t, err, "invalid secret")
+
+		srs1.Contribute()
+		buf.Reset()
+		_, err = srs1.WriteTo(buf)
+		require.NoError(t, err)
+
+		_, err = ReadPhase1(buf, cs)
+		require.NoError(t, err)
+	})
+
+	t.Run("small phase 1", func(t *testing.T) {
+		_, ptau := newPtau(t, power-1)
+		_, err := ReadPhase1(bytes.NewReader(ptau), cs)
+		require.ErrorContains(t, err, "the circuit requires power 9")
+	})
+
+	t.Run("plonk", func(t *testing.T) {
+		plonkCs, err := Compile(BackendPlonk, crop, pixels.Shape(), pixels.Shape(), params, false)
+		require.NoError(t, err)
+
+		_, err = InitPhase2(plonkCs, phase1)
+		require.ErrorContains(t, err, "requires the groth16 backend")
+	})
+}
@@ -0,0 +1,214 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(colorMatrix{})
+}
+
+const (
+	// maxMatrixCoefficient is the maximum absolute coefficient of color matrices.
+	maxMatrixCoefficient = 16
+	// maxMatrixOffset is the maximum absolute offset of color matrices.
+	maxMatrixOffset = 4096
+)
+
+// matrixPresets are the preset color matrices, with a row of RGB coefficients and an offset per final channel.
+var matrixPresets = map[string][3][4]float64{
+	"invert": {
+		{-1, 0, 0, 255},
+		{0, -1, 0, 255},
+		{0, 0, -1, 255},
+	},
+	"sepia": {
+		{0.393, 0.769, 0.189, 0},
+		{0.349, 0.686, 0.168, 0},
+		{0.272, 0.534, 0.131, 0},
+	},
+}
+
+// colorMatrix multiplies the RGB channels of every pixel of the original image by a fixed-point color matrix.
+type colorMatrix struct{}
+
+func (colorMatrix) Name() string {
+	return "color-matrix"
+}
+
+func (colorMatrix) Description() string {
+	return "Applies a color matrix to the original image, e.g. to invert it or tone it sepia."
+}
+
+func (colorMatrix) Params() []Param {
+	return []Param{
+		{
+			Name: "matrix",
+			Usage: "The color matrix, either a preset or 9 comma separated coefficients of a 3x3 matrix, or 12 of a 3x4 matrix with an offset per row, in row-major order. " +
+				"Coefficients are in [-16, 16] and offsets in [-4096, 4096], rounded to a multiple of 1/256. Offsets are multiplied by 257 for 16-bit images. Presets: invert and sepia.",
+			Kind:    ParamString,
+			Default: "sepia",
+			Public:  true,
+		},
+	}
+}
+
+func (c colorMatrix) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The matrix is a public input, so the circuit does not depend on it, but it is checked to fail early.
+	if _, err := c.matrix(params, original.BitDepth()); err != nil {
+		return nil, err
+	}
+
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &ColorMatrixCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Filtered:   final.variables(),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (c colorMatrix) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	bitDepth := final.Shape().BitDepth()
+
+	matrix, err := c.matrix(params, bitDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := &ColorMatrixCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Filtered:   final.variables(),
+		BitDepth:   bitDepth,
+	}
+	for i := range matrix {
+		for j := range matrix[i] {
+			assignment.Matrix[i][j] = matrix[i][j]
+		}
+	}
+
+	return assignment, nil
+}
+
+func (c colorMatrix) Apply(original Pixels, params Params) (Pixels, error) {
+	shape := original.Shape()
+
+	matrix, err := c.matrix(params, shape.BitDepth())
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	if err := shape.validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(shape)
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			for k := 0; k < 3; k++ {
+				v := matrix[k][3]
+				for l := 0; l < 3; l++ {
+					v += matrix[k][l] * int(original.Values[i][j][l])
+				}
+
+				resp.Values[i][j][k] = toPixel(v, shape.MaxValue())
+			}
+			copyAlpha(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// matrix returns the fixed-point 3x4 color matrix of the parameters, checking its coefficients and offsets.
+// The offsets of 3x3 matrices are zero, the others are scaled to channel values of the bit depth.
+func (colorMatrix) matrix(params Params, bitDepth int) ([3][4]int, error) {
+	value := params.String("matrix")
+
+	matrix, ok := matrixPresets[value]
+	if !ok {
+		values := strings.Split(value, ",")
+		if len(values) != 9 && len(values) != 12 {
+			return [3][4]int{}, fmt.Errorf("invalid matrix %q, expected a preset or 9 or 12 comma separated numbers", value)
+		}
+
+		columns := len(values) / 3
+		for i, s := range values {
+			v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return [3][4]int{}, fmt.Errorf("invalid matrix %q, expected a preset or 9 or 12 comma separated numbers", value)
+			}
+
+			matrix[i/columns][i%columns] = v
+		}
+	}
+
+	var resp [3][4]int
+	for i := range matrix {
+		for j, v := range matrix[i] {
+			limit := float64(maxMatrixCoefficient)
+			if j == 3 {
+				limit = maxMatrixOffset
+			}
+
+			if v < -limit || v > limit {
+				return [3][4]int{}, fmt.Errorf("matrix value %v out of range [-%v, %v]", v, limit, limit)
+			}
+
+			resp[i][j] = toFixedPoint(v)
+			if j == 3 {
+				resp[i][j] *= depthScale(bitDepth)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// ColorMatrixCircuit represents the arithmetic circuit to prove color-matrix transformations.
+// The matrix is a public input in fixed point, with a row of RGB coefficients and an offset per final channel in
+// channel values of the bit depth.
+type ColorMatrixCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Filtered   [][][]frontend.Variable `gnark:",public"`
+	Matrix     [3][4]frontend.Variable `gnark:",public"`
+	BitDepth   int
+}
+
+func (c *ColorMatrixCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	// The verifier checks the ranges of the matrix values, see colorMatrix.matrix.
+	bound := 3*maxValue(c.BitDepth)*toFixedPoint(maxMatrixCoefficient) + toFixedPoint(maxMatrixOffset)*depthScale(c.BitDepth)
+
+	// Every final channel value must be the rounded and clamped product of its matrix row and the original pixel.
+	for i := 0; i < len(c.Original); i++ {
+		for j := 0; j < len(c.Original[i]); j++ {
+			for k := 0; k < 3; k++ {
+				v := c.Matrix[k][3]
+				for l := 0; l < 3; l++ {
+					v = api.Add(v, api.Mul(c.Original[i][j][l], c.Matrix[k][l]))
+				}
+
+				assertToPixel(api, v, c.Filtered[i][j][k], bound, maxValue(c.BitDepth))
+			}
+			assertAlpha(api, c.Original[i][j], c.Filtered[i][j])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestColorMatrix(t *testing.T) {
+	tr, err := Get("color-matrix")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 100, 255}, {10, 20, 30}}}}
+
+	tests := []struct {
+		name   string
+		params Params
+		final  Pixels
+	}{
+		{
+			name:   "invert",
+			params: Params{"matrix": "invert"},
+			final:  Pixels{Values: [][][]uint16{{{255, 155, 0}, {245, 235, 225}}}},
+		},
+		{
+			// The red row is 101/256, 197/256 and 48/256, e.g. (101*10 + 197*20 + 48*30) / 256 = 24.96 -> 25.
+			name:   "sepia",
+			params: Params{},
+			final:  Pixels{Values: [][][]uint16{{{125, 112, 87}, {25, 22, 17}}}},
+		},
+		{
+			name:   "3x3",
+			params: Params{"matrix": "0,0,1, 0,1,0, 1,0,0"},
+			final:  Pixels{Values: [][][]uint16{{{255, 100, 0}, {30, 20, 10}}}},
+		},
+		{
+			// 0.5*10 + 10 = 15, 20 - 20 = 0 and 2*255 is clamped.
+			name:   "3x4",
+			params: Params{"matrix": "0.5,0,0,10, 0,1,0,-20, 0,0,2,0"},
+			final:  Pixels{Values: [][][]uint16{{{10, 80, 255}, {15, 0, 60}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := tr.Apply(original, tt.params.WithDefaults(tr))
+			require.NoError(t, err)
+			require.Equal(t, tt.final, final)
+		})
+	}
+
+	for _, tt := range []struct {
+		params Params
+		err    string
+	}{
+		{params: Params{"matrix": "grayscale"}, err: "invalid matrix"},
+		{params: Params{"matrix": "1,0,0,0,1,0,0,0,x"}, err: "invalid matrix"},
+		{params: Params{"matrix": "1,0,0,0,1,0,0,0,-17"}, err: "out of range"},
+		{params: Params{"matrix": "1,0,0,0,0,1,0,0,0,0,1,4097"}, err: "out of range"},
+	} {
+		_, err = tr.Apply(original, tt.params.WithDefaults(tr))
+		require.ErrorContains(t, err, tt.err)
+	}
+}
+
+func TestColorMatrixPublicMatrix(t *testing.T) {
+	tr, err := Get("color-matrix")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 100, 255}, {10, 20, 30}}}}
+	params := Params{"matrix": "invert"}.WithDefaults(tr)
+
+	final, err := tr.Apply(original, params)
+	require.NoError(t, err)
+
+	// The circuit does not depend on the matrix, so the keys can be reused for any matrix.
+	cs, err := Compile(BackendGroth16, tr, original.Shape(), final.Shape(), Params{}.WithDefaults(tr), false)
+	require.NoError(t, err)
+
+	pk, vk, err := Setup(BackendGroth16, cs, nil)
+	require.NoError(t, err)
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	proof, err := Prove(BackendGroth16, cs, pk, tr, original, final, params, provenance)
+	require.NoError(t, err)
+	require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, params, provenance))
+
+	// The verifier provides the matrix claimed by the prover.
+	for _, other := range []Params{
+		{"matrix": "sepia"},
+		{"matrix": "-1,0,0,255, 0,-1,0,255, 0,0,-1,254"},
+	} {
+		err = Verify(BackendGroth16, tr, proof, vk, final, other.WithDefaults(tr), provenance)
+		require.Error(t, err)
+	}
+}
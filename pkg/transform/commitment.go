@@ -0,0 +1,141 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	stdmimc "github.com/consensys/gnark/std/hash/mimc"
+	"math/big"
+)
+
+// packedBits is the number of bits of channel values packed into a single field element before hashing.
+// 240 bits always fit in a BN254 scalar field element, i.e. 10 RGB pixels with 8-bit channels or 5 with 16-bit channels.
+const packedBits = 240
+
+// Hash returns the MiMC hash of the image dimensions followed by the packed channel values.
+// This is the public commitment to the original image that every transformation circuit checks.
+func (p Pixels) Hash() (*big.Int, error) {
+	if err := p.Shape().validate(); err != nil {
+		return nil, err
+	}
+
+	h := mimc.NewMiMC()
+
+	var elem fr.Element
+	write := func(v *big.Int) error {
+		elem.SetBigInt(v)
+		b := elem.Bytes()
+		_, err := h.Write(b[:])
+		return err
+	}
+
+	shape := p.Shape()
+	if err := write(big.NewInt(int64(shape.Height))); err != nil {
+		return nil, err
+	}
+	if err := write(big.NewInt(int64(shape.Width))); err != nil {
+		return nil, err
+	}
+	if tag := formatTag(shape.Channels(), shape.BitDepth()); tag != nil {
+		if err := write(tag); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, chunk := range chunkPixels(flattenPixels(p.Values), shape.BitDepth()) {
+		packed := new(big.Int)
+		for _, v := range chunk {
+			packed.Lsh(packed, uint(shape.BitDepth()))
+			packed.Add(packed, big.NewInt(int64(v)))
+		}
+
+		if err := write(packed); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// assertPixelsHash constrains hash to be the MiMC hash of the provided pixels with channel values of the bit depth,
+// see Pixels.Hash.
+func assertPixelsHash(api frontend.API, pixels [][][]frontend.Variable, bitDepth int, hash frontend.Variable) error {
+	h, err := stdmimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	h.Write(len(pixels), len(pixels[0]))
+	if tag := formatTag(len(pixels[0][0]), bitDepth); tag != nil {
+		h.Write(tag)
+	}
+
+	for _, chunk := range chunkPixels(flattenPixels(pixels), bitDepth) {
+		var packed frontend.Variable = 0
+		for _, v := range chunk {
+			// Packing is only injective if every channel fits in the bit depth.
+			api.ToBinary(v, bitDepth)
+			packed = api.Add(api.Mul(packed, 1<<bitDepth), v)
+		}
+
+		h.Write(packed)
+	}
+
+	api.AssertIsEqual(h.Sum(), hash)
+
+	return nil
+}
+
+// ParseHash parses a hex (0x-prefixed) or decimal image hash.
+func ParseHash(s string) (*big.Int, error) {
+	hash, ok := new(big.Int).SetString(s, 0)
+	if !ok || hash.Sign() < 0 || hash.Cmp(fr.Modulus()) >= 0 {
+		return nil, fmt.Errorf("invalid image hash, %s", s)
+	}
+
+	return hash, nil
+}
+
+// FormatHash returns the 0x-prefixed hex encoding of the image hash.
+func FormatHash(hash *big.Int) string {
+	return fmt.Sprintf("0x%064x", hash)
+}
+
+// flattenPixels returns the channel values of the pixels in row-major order, including the alpha channel if any.
+func flattenPixels[T any](pixels [][][]T) []T {
+	var resp []T
+	for i := range pixels {
+		for j := range pixels[i] {
+			resp = append(resp, pixels[i][j]...)
+		}
+	}
+
+	return resp
+}
+
+// formatTag returns the element hashed after the image dimensions of pixels with the number of channels and bit depth,
+// or nil for RGB pixels with 8-bit channels, whose hashes predate other formats. Packed channel values are below
+// 2^240 and the tag is not, so pixels of different formats never hash alike, e.g. a transparent red RGBA pixel like
+// an RGB pixel.
+func formatTag(channels, bitDepth int) *big.Int {
+	if channels == 3 && bitDepth == 8 {
+		return nil
+	}
+
+	tag := new(big.Int).Lsh(big.NewInt(1), packedBits)
+
+	return tag.Add(tag, big.NewInt(int64(bitDepth<<8|channels)))
+}
+
+// chunkPixels splits channel values of the bit depth into chunks that are packed into a single field element.
+func chunkPixels[T any](values []T, bitDepth int) [][]T {
+	size := packedBits / bitDepth
+
+	var resp [][]T
+	for start := 0; start < len(values); start += size {
+		resp = append(resp, values[start:min(start+size, len(values))])
+	}
+
+	return resp
+}
@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+// hashCircuit checks that the hash of Pixels with channel values of BitDepth equals Hash.
+type hashCircuit struct {
+	Hash     frontend.Variable       `gnark:",public"`
+	Pixels   [][][]frontend.Variable `gnark:",secret"`
+	BitDepth int
+}
+
+func (c *hashCircuit) Define(api frontend.API) error {
+	return assertPixelsHash(api, c.Pixels, c.BitDepth, c.Hash)
+}
+
+func TestHashPixels(t *testing.T) {
+	pixels := loadPixels(t, "../../sample/original.png")
+
+	hash, err := pixels.Hash()
+	require.NoError(t, err)
+
+	circuit := &hashCircuit{Pixels: pixels.Shape().variables(), BitDepth: 8}
+
+	// The in-circuit hash must match the native hash.
+	err = test.IsSolved(circuit, &hashCircuit{
+		Hash:   hash,
+		Pixels: pixels.variables(),
+	}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+
+	// A different image must not match the hash.
+	pixels.Values[0][0][0]++
+	err = test.IsSolved(circuit, &hashCircuit{
+		Hash:   hash,
+		Pixels: pixels.variables(),
+	}, ecc.BN254.ScalarField())
+	require.Error(t, err)
+}
+
+func TestHashAlpha(t *testing.T) {
+	rgba := Pixels{Values: [][][]uint16{{{0, 10, 20, 30}}}}
+
+	hash, err := rgba.Hash()
+	require.NoError(t, err)
+
+	// The RGB pixel packs to the same value as the RGBA pixel.
+	rgb, err := Pixels{Values: [][][]uint16{{{10, 20, 30}}}}.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, rgb, hash)
+
+	err = test.IsSolved(&hashCircuit{Pixels: rgba.Shape().variables(), BitDepth: 8}, &hashCircuit{
+		Hash:   hash,
+		Pixels: rgba.variables(),
+	}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+}
+
+func TestHashDepth16(t *testing.T) {
+	pixels := Pixels{Values: [][][]uint16{{{0, 10, 20}, {30, 40, 50}}}}
+
+	hash8, err := pixels.Hash()
+	require.NoError(t, err)
+
+	// The same channel values with 16 bits pack differently, so the hashes differ.
+	pixels.Depth16 = true
+
+	hash, err := pixels.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash8, hash)
+
+	circuit := &hashCircuit{Pixels: pixels.Shape().variables(), BitDepth: 16}
+
+	err = test.IsSolved(circuit, &hashCircuit{
+		Hash:   hash,
+		Pixels: pixels.variables(),
+	}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+
+	// The least significant bit is committed to.
+	pixels.Values[0][1][0] ^= 1
+	err = test.IsSolved(circuit, &hashCircuit{
+		Hash:   hash,
+		Pixels: pixels.variables(),
+	}, ecc.BN254.ScalarField())
+	require.Error(t, err)
+}
+
+func TestParseHash(t *testing.T) {
+	hash := big.NewInt(12345)
+
+	parsed, err := ParseHash(FormatHash(hash))
+	require.NoError(t, err)
+	require.Equal(t, hash, parsed)
+
+	_, err = ParseHash("not a hash")
+	require.Error(t, err)
+
+	_, err = ParseHash("")
+	require.Error(t, err)
+}
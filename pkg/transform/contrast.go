@@ -0,0 +1,156 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+)
+
+func init() {
+	Register(contrast{})
+}
+
+const (
+	// contrastPivot is the channel value contrast is adjusted around.
+	contrastPivot = 128
+	// maxContrastFactor is the maximum contrast factor.
+	maxContrastFactor = 16
+)
+
+// contrast adjusts the contrast of the original image by a fixed-point factor.
+type contrast struct{}
+
+func (contrast) Name() string {
+	return "contrast"
+}
+
+func (contrast) Description() string {
+	return "Adjusts the contrast of the original image by a factor."
+}
+
+func (contrast) Params() []Param {
+	return []Param{
+		{
+			Name:    "factor",
+			Usage:   "The contrast factor in [0, 16], rounded to a multiple of 1/256. Factors below 1 reduce contrast, above 1 increase it.",
+			Kind:    ParamFloat,
+			Default: "1",
+			Public:  true,
+		},
+	}
+}
+
+func (c contrast) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The factor is a public input, so the circuit does not depend on it, but it is checked to fail early.
+	if _, err := c.factor(params); err != nil {
+		return nil, err
+	}
+
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &ContrastCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Adjusted:   final.variables(),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (c contrast) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	factor, err := c.factor(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContrastCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Adjusted:   final.variables(),
+		Factor:     factor,
+		BitDepth:   final.Shape().BitDepth(),
+	}, nil
+}
+
+func (c contrast) Apply(original Pixels, params Params) (Pixels, error) {
+	factor, err := c.factor(params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	if err := original.Shape().validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	shape := original.Shape()
+	offset := contrastOffset(factor, shape.BitDepth())
+
+	resp := NewPixels(shape)
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			for k := 0; k < 3; k++ {
+				resp.Values[i][j][k] = affine(int(original.Values[i][j][k]), factor, offset, shape.MaxValue())
+			}
+			copyAlpha(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// factor returns the fixed-point contrast factor, checking its range.
+func (contrast) factor(params Params) (int, error) {
+	factor, err := params.Float("factor")
+	if err != nil {
+		return 0, err
+	}
+
+	if factor < 0 || factor > maxContrastFactor {
+		return 0, fmt.Errorf("contrast factor %v out of range [0, %d]", factor, maxContrastFactor)
+	}
+
+	return toFixedPoint(factor), nil
+}
+
+// contrastOffset returns the fixed-point offset of the contrast factor for channel values of the bit depth, so
+// affine(value, factor, offset) is (value - pivot) * factor + pivot, with the pivot 128 scaled to the bit depth.
+func contrastOffset(factor, bitDepth int) int {
+	pivot := contrastPivot * depthScale(bitDepth)
+
+	return pivot<<fixedPointShift - pivot*factor
+}
+
+// ContrastCircuit represents the arithmetic circuit to prove contrast transformations.
+// The contrast factor is a public input in fixed point, see affine.
+type ContrastCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Adjusted   [][][]frontend.Variable `gnark:",public"`
+	Factor     frontend.Variable       `gnark:",public"`
+	BitDepth   int
+}
+
+func (c *ContrastCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	// The verifier checks the range of the factor, see contrast.factor, so the offset is at most pivot * maxFactor.
+	pivot, maxFactor := contrastPivot*depthScale(c.BitDepth), toFixedPoint(maxContrastFactor)
+	offset := api.Sub(pivot<<fixedPointShift, api.Mul(c.Factor, pivot)) // See contrastOffset.
+
+	for i := 0; i < len(c.Original); i++ {
+		for j := 0; j < len(c.Original[i]); j++ {
+			for k := 0; k < 3; k++ {
+				assertAffine(api, c.Original[i][j][k], c.Adjusted[i][j][k], c.Factor, offset, maxFactor, pivot*maxFactor, maxValue(c.BitDepth))
+			}
+			assertAlpha(api, c.Original[i][j], c.Adjusted[i][j])
+		}
+	}
+
+	return nil
+}
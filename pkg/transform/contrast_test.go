@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestContrast(t *testing.T) {
+	tr, err := Get("contrast")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 64, 127}, {128, 129, 200}, {255, 250, 10}}}}
+
+	tests := []struct {
+		factor string
+		final  Pixels
+	}{
+		{
+			factor: "1",
+			final:  original,
+		},
+		{
+			// (v - 128) * 1.25 + 128, e.g. 127 -> 126.75 -> 127, 129 -> 129.25 -> 129, 200 -> 218.
+			factor: "1.25",
+			final:  Pixels{Values: [][][]uint16{{{0, 48, 127}, {128, 129, 218}, {255, 255, 0}}}},
+		},
+		{
+			// 64 -> 96, 127 -> 127.5 -> 128 as halves are rounded up, 10 -> 69.
+			factor: "0.5",
+			final:  Pixels{Values: [][][]uint16{{{64, 96, 128}, {128, 129, 164}, {192, 189, 69}}}},
+		},
+		{
+			factor: "0",
+			final:  Pixels{Values: [][][]uint16{{{128, 128, 128}, {128, 128, 128}, {128, 128, 128}}}},
+		},
+		{
+			// 1.3 is rounded to 333/256, 64 -> 44.75 -> 45.
+			factor: "1.3",
+			final:  Pixels{Values: [][][]uint16{{{0, 45, 127}, {128, 129, 222}, {255, 255, 0}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.factor, func(t *testing.T) {
+			final, err := tr.Apply(original, Params{"factor": tt.factor})
+			require.NoError(t, err)
+			require.Equal(t, tt.final, final)
+		})
+	}
+
+	for _, factor := range []string{"-0.5", "16.5"} {
+		_, err = tr.Apply(original, Params{"factor": factor})
+		require.ErrorContains(t, err, "out of range")
+	}
+}
+
+func TestContrastPublicFactor(t *testing.T) {
+	tr, err := Get("contrast")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 64, 127}, {128, 129, 200}, {255, 250, 10}}}}
+	params := Params{"factor": "1.3"}
+
+	final, err := tr.Apply(original, params)
+	require.NoError(t, err)
+
+	// The circuit does not depend on the factor, so the keys can be reused for any factor.
+	cs, err := Compile(BackendGroth16, tr, original.Shape(), final.Shape(), Params{}.WithDefaults(tr), false)
+	require.NoError(t, err)
+
+	pk, vk, err := Setup(BackendGroth16, cs, nil)
+	require.NoError(t, err)
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	proof, err := Prove(BackendGroth16, cs, pk, tr, original, final, params, provenance)
+	require.NoError(t, err)
+	require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, params, provenance))
+
+	// The verifier provides the factor claimed by the prover.
+	err = Verify(BackendGroth16, tr, proof, vk, final, Params{"factor": "1.25"}, provenance)
+	require.Error(t, err)
+}
@@ -0,0 +1,339 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(convolve{})
+}
+
+const (
+	BorderClamp  = "clamp"
+	BorderZero   = "zero"
+	BorderMirror = "mirror"
+)
+
+const (
+	// maxKernelSize is the maximum width and height of convolution kernels.
+	maxKernelSize = 7
+	// maxKernelWeight is the maximum absolute weight of convolution kernels.
+	maxKernelWeight = 1024
+	// maxConvolveDivisor is the maximum divisor of convolution kernels.
+	maxConvolveDivisor = 1 << 16
+)
+
+// kernelPresets are the weights of the preset convolution kernels in row-major order.
+var kernelPresets = map[string][]int{
+	"blur3": {
+		1, 2, 1,
+		2, 4, 2,
+		1, 2, 1,
+	},
+	"blur5": {
+		1, 4, 6, 4, 1,
+		4, 16, 24, 16, 4,
+		6, 24, 36, 24, 6,
+		4, 16, 24, 16, 4,
+		1, 4, 6, 4, 1,
+	},
+	"sharpen": {
+		0, -1, 0,
+		-1, 5, -1,
+		0, -1, 0,
+	},
+	"edge": {
+		-1, -1, -1,
+		-1, 8, -1,
+		-1, -1, -1,
+	},
+}
+
+// convolve convolves the original image with an integer kernel divided by a divisor.
+type convolve struct{}
+
+func (convolve) Name() string {
+	return "convolve"
+}
+
+func (convolve) Description() string {
+	return "Convolves the original image with a kernel, e.g. to blur or sharpen it."
+}
+
+func (convolve) Params() []Param {
+	return []Param{
+		{
+			Name: "kernel",
+			Usage: "The convolution kernel, either a preset or the comma separated integer weights of a square kernel of odd size up to 7 in row-major order. " +
+				"Presets: blur3, blur5, sharpen and edge.",
+			Kind:    ParamString,
+			Default: "blur3",
+			Public:  true,
+		},
+		{
+			Name:    "divisor",
+			Usage:   "The divisor of the kernel sum in [1, 65536], zero for the sum of the kernel weights, or 1 if it isn't positive.",
+			Kind:    ParamInt,
+			Default: "0",
+			Public:  true,
+		},
+		{
+			Name:    "border",
+			Usage:   "The value of pixels beyond the edges of the original image. Supported: clamp, zero and mirror.",
+			Kind:    ParamString,
+			Default: BorderClamp,
+		},
+	}
+}
+
+func (c convolve) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The kernel weights and divisor are public inputs, so the circuit only depends on the kernel size,
+	// but they are checked to fail early.
+	k, err := c.kernel(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &ConvolveCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Convolved:  final.variables(),
+		Weights:    make([]frontend.Variable, len(k.weights)),
+		Size:       k.size,
+		Border:     k.border,
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (c convolve) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	k, err := c.kernel(params)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]frontend.Variable, len(k.weights))
+	for i, w := range k.weights {
+		weights[i] = w
+	}
+
+	return &ConvolveCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Convolved:  final.variables(),
+		Weights:    weights,
+		Divisor:    k.divisor,
+		Size:       k.size,
+		Border:     k.border,
+		BitDepth:   final.Shape().BitDepth(),
+	}, nil
+}
+
+func (c convolve) Apply(original Pixels, params Params) (Pixels, error) {
+	k, err := c.kernel(params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	shape := original.Shape()
+	if err := shape.validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(shape)
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			for ch := 0; ch < 3; ch++ {
+				var sum int
+				for _, tap := range k.taps(shape, i, j) {
+					sum += k.weights[tap.index] * int(original.Values[tap.row][tap.col][ch])
+				}
+
+				resp.Values[i][j][ch] = uint16(clampInt(2*sum+k.divisor, 0, 2*k.divisor*(shape.MaxValue()+1)-1) / (2 * k.divisor))
+			}
+			// The kernel only applies to the colors, e.g. edge detection must not make opaque images transparent.
+			copyAlpha(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// kernel is a square convolution kernel with its divisor and border mode.
+type kernel struct {
+	weights []int
+	size    int
+	divisor int
+	border  string
+}
+
+// kernelTap is an original pixel in the kernel sum of a final pixel, with the index of its kernel weight.
+type kernelTap struct {
+	row, col, index int
+}
+
+// taps returns the original pixels in the kernel sum of the final pixel at row i and column j.
+// Taps beyond the edges of the image are mapped by the border mode, or omitted for zero borders.
+func (k kernel) taps(shape Shape, i, j int) []kernelTap {
+	radius := k.size / 2
+
+	var resp []kernelTap
+	for ki := 0; ki < k.size; ki++ {
+		for kj := 0; kj < k.size; kj++ {
+			row, okRow := borderIndex(k.border, i+ki-radius, shape.Height)
+			col, okCol := borderIndex(k.border, j+kj-radius, shape.Width)
+			if okRow && okCol {
+				resp = append(resp, kernelTap{row: row, col: col, index: ki*k.size + kj})
+			}
+		}
+	}
+
+	return resp
+}
+
+// borderIndex returns the index in [0, n) of index i by border mode, or false if the pixel is zero:
+//   - clamp: the nearest edge pixel.
+//   - zero: none, the pixel is zero.
+//   - mirror: the pixel reflected at the edge pixel, which isn't repeated, e.g. -1 is 1 and n is n-2.
+func borderIndex(border string, i, n int) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+
+	switch border {
+	case BorderZero:
+		return 0, false
+	case BorderMirror:
+		if n == 1 {
+			return 0, true
+		}
+
+		// Reflections repeat with period 2(n-1).
+		period := 2 * (n - 1)
+		i = (i%period + period) % period
+		if i >= n {
+			i = period - i
+		}
+
+		return i, true
+	default:
+		return clampInt(i, 0, n-1), true
+	}
+}
+
+// kernel returns the kernel of the parameters, checking its weights, size and divisor.
+func (convolve) kernel(params Params) (kernel, error) {
+	value := params.String("kernel")
+
+	weights, ok := kernelPresets[value]
+	if !ok {
+		for _, s := range strings.Split(value, ",") {
+			w, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return kernel{}, fmt.Errorf("invalid kernel %q, expected a preset or comma separated integers", value)
+			}
+
+			if w < -maxKernelWeight || w > maxKernelWeight {
+				return kernel{}, fmt.Errorf("kernel weight %d out of range [-%d, %d]", w, maxKernelWeight, maxKernelWeight)
+			}
+
+			weights = append(weights, w)
+		}
+	}
+
+	size := 1
+	for size*size < len(weights) {
+		size++
+	}
+
+	if size*size != len(weights) || size%2 == 0 || size > maxKernelSize {
+		return kernel{}, fmt.Errorf("invalid kernel size, %d weights is not a square of odd size up to %d", len(weights), maxKernelSize)
+	}
+
+	divisor, err := params.Int("divisor")
+	if err != nil {
+		return kernel{}, err
+	}
+
+	if divisor == 0 {
+		for _, w := range weights {
+			divisor += w
+		}
+		divisor = max(divisor, 1)
+	}
+
+	if divisor < 1 || divisor > maxConvolveDivisor {
+		return kernel{}, fmt.Errorf("divisor %d out of range [1, %d]", divisor, maxConvolveDivisor)
+	}
+
+	switch border := params.String("border"); border {
+	case BorderClamp, BorderZero, BorderMirror:
+	default:
+		return kernel{}, fmt.Errorf("unsupported border, %s", border)
+	}
+
+	return kernel{weights: weights, size: size, divisor: divisor, border: params.String("border")}, nil
+}
+
+// ConvolveCircuit represents the arithmetic circuit to prove convolve transformations.
+// The kernel weights in row-major order and the divisor are public inputs, see convolve.kernel.
+type ConvolveCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Convolved  [][][]frontend.Variable `gnark:",public"`
+	Weights    []frontend.Variable     `gnark:",public"`
+	Divisor    frontend.Variable       `gnark:",public"`
+	Size       int
+	Border     string
+	BitDepth   int
+}
+
+func (c *ConvolveCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	shape := Shape{Width: len(c.Original[0]), Height: len(c.Original)}
+
+	// The taps only depend on the kernel size and border mode.
+	k := kernel{size: c.Size, border: c.Border}
+
+	// The verifier checks the ranges of the weights and divisor, so the rounded kernel sum is at most
+	// 2 * max * size^2 * 1024 + 65536 away from 0, and 2 * (max+1) * 65536 further from its clamped upper bound,
+	// where max is the maximum channel value, e.g. 255.
+	hiValue := maxValue(c.BitDepth)
+	bound := 2*hiValue*c.Size*c.Size*maxKernelWeight + 2*(hiValue+2)*maxConvolveDivisor
+
+	divisor := api.Mul(c.Divisor, 2)
+	hi := api.Sub(api.Mul(divisor, hiValue+1), 1)
+
+	// Every final pixel value must be the rounded and clamped kernel sum divided by the divisor, see convolve.Apply.
+	// Rounding to the nearest integer with halves rounded up is flooring (2*sum + divisor) / (2*divisor).
+	for i := 0; i < shape.Height; i++ {
+		for j := 0; j < shape.Width; j++ {
+			taps := k.taps(shape, i, j)
+			for ch := 0; ch < 3; ch++ {
+				sum := frontend.Variable(0)
+				for _, tap := range taps {
+					sum = api.Add(sum, api.Mul(c.Original[tap.row][tap.col][ch], c.Weights[tap.index]))
+				}
+
+				v := clamp(api, api.Add(api.Mul(sum, 2), c.Divisor), 0, hi, bound)
+				assertDivVariable(api, v, c.Convolved[i][j][ch], divisor, 2*maxConvolveDivisor)
+			}
+			assertAlpha(api, c.Original[i][j], c.Convolved[i][j])
+		}
+	}
+
+	return nil
+}
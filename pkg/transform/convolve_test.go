@@ -0,0 +1,126 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestConvolve(t *testing.T) {
+	tr, err := Get("convolve")
+	require.NoError(t, err)
+
+	// A single row of gray pixels, so rows beyond the edges only depend on the border mode.
+	original := Pixels{Values: [][][]uint16{{{0, 0, 0}, {90, 90, 90}, {180, 180, 180}}}}
+
+	tests := []struct {
+		name   string
+		params Params
+		final  []uint16
+	}{
+		{
+			// The column weights are 4, 8 and 4, e.g. (4*0 + 8*0 + 4*90) / 16 = 22.5 -> 23.
+			name:   "blur3 clamp",
+			params: Params{},
+			final:  []uint16{23, 90, 158},
+		},
+		{
+			// Only the middle kernel row is in the image, e.g. (4*0 + 2*90) / 16 = 11.25 -> 11.
+			name:   "blur3 zero",
+			params: Params{"border": "zero"},
+			final:  []uint16{11, 45, 56},
+		},
+		{
+			// Column -1 is column 1 and column 3 is column 1, e.g. (4*90 + 8*0 + 4*90) / 16 = 45.
+			name:   "blur3 mirror",
+			params: Params{"border": "mirror"},
+			final:  []uint16{45, 90, 135},
+		},
+		{
+			// The column weights are -3, 6 and -3, and the sums -270, 0 and 270 are clamped.
+			name:   "edge",
+			params: Params{"kernel": "edge"},
+			final:  []uint16{0, 0, 255},
+		},
+		{
+			// (2*180) / 3 = 120 and (2*90) / 3 = 60.
+			name:   "custom kernel and divisor",
+			params: Params{"kernel": "0, 0, 0, 0, 2, 0, 0, 0, 0", "divisor": "3"},
+			final:  []uint16{0, 60, 120},
+		},
+		{
+			name:   "identity",
+			params: Params{"kernel": "1"},
+			final:  []uint16{0, 90, 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := tr.Apply(original, tt.params.WithDefaults(tr))
+			require.NoError(t, err)
+
+			want := NewPixels(original.Shape())
+			for j, v := range tt.final {
+				want.Values[0][j] = []uint16{v, v, v}
+			}
+			require.Equal(t, want, final)
+		})
+	}
+
+	for _, tt := range []struct {
+		params Params
+		err    string
+	}{
+		{params: Params{"kernel": "blur"}, err: "invalid kernel"},
+		{params: Params{"kernel": "1,x,1"}, err: "invalid kernel"},
+		{params: Params{"kernel": "1,2"}, err: "invalid kernel size"},
+		{params: Params{"kernel": "1,1,1,1"}, err: "invalid kernel size"},
+		{params: Params{"kernel": "1025"}, err: "out of range"},
+		{params: Params{"divisor": "65537"}, err: "out of range"},
+		{params: Params{"divisor": "-1"}, err: "out of range"},
+		{params: Params{"border": "wrap"}, err: "unsupported border"},
+	} {
+		_, err = tr.Apply(original, tt.params.WithDefaults(tr))
+		require.ErrorContains(t, err, tt.err)
+	}
+}
+
+func TestConvolvePublicKernel(t *testing.T) {
+	tr, err := Get("convolve")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{
+		{{0, 10, 20}, {90, 100, 110}},
+		{{180, 190, 200}, {250, 255, 5}},
+	}}
+	params := Params{"kernel": "sharpen"}.WithDefaults(tr)
+
+	final, err := tr.Apply(original, params)
+	require.NoError(t, err)
+
+	// The circuit only depends on the kernel size, so the keys can be reused for any 3x3 kernel.
+	cs, err := Compile(BackendGroth16, tr, original.Shape(), final.Shape(), Params{}.WithDefaults(tr), false)
+	require.NoError(t, err)
+
+	pk, vk, err := Setup(BackendGroth16, cs, nil)
+	require.NoError(t, err)
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	proof, err := Prove(BackendGroth16, cs, pk, tr, original, final, params, provenance)
+	require.NoError(t, err)
+	require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, params, provenance))
+
+	// The verifier provides the kernel and divisor claimed by the prover.
+	for _, other := range []Params{
+		{"kernel": "blur3"},
+		{"kernel": "sharpen", "divisor": "2"},
+	} {
+		err = Verify(BackendGroth16, tr, proof, vk, final, other.WithDefaults(tr), provenance)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,353 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"math/bits"
+	"strconv"
+)
+
+func init() {
+	Register(crop{})
+}
+
+// crop crops the original image to the final image at the provided offsets.
+type crop struct{}
+
+func (crop) Name() string {
+	return "crop"
+}
+
+func (crop) Description() string {
+	return "Crops the original image."
+}
+
+func (crop) Params() []Param {
+	return []Param{
+		{
+			Name:    "width-start-new",
+			Usage:   "The x offset of the crop's top-left corner in the original image.",
+			Kind:    ParamInt,
+			Default: "0",
+			Public:  true,
+		},
+		{
+			Name:    "height-start-new",
+			Usage:   "The y offset of the crop's top-left corner in the original image.",
+			Kind:    ParamInt,
+			Default: "0",
+			Public:  true,
+		},
+		{
+			Name:    "width-new",
+			Usage:   "The width of the cropped image, zero to crop up to the right edge of the original image.",
+			Kind:    ParamInt,
+			Default: "0",
+		},
+		{
+			Name:    "height-new",
+			Usage:   "The height of the cropped image, zero to crop up to the bottom edge of the original image.",
+			Kind:    ParamInt,
+			Default: "0",
+		},
+		{
+			Name:    "original-width",
+			Usage:   "The width of the original image, zero for the width of the provided original image.",
+			Kind:    ParamInt,
+			Default: "0",
+			Public:  true,
+		},
+		{
+			Name:    "original-height",
+			Usage:   "The height of the original image, zero for the height of the provided original image.",
+			Kind:    ParamInt,
+			Default: "0",
+			Public:  true,
+		},
+	}
+}
+
+// Resolve sets the original image dimensions to the original shape if they are zero, so provers print them and
+// verifiers use the dimensions recorded by the prover.
+func (crop) Resolve(original, _ Shape, params Params) Params {
+	resp := params.WithDefaults(crop{})
+	if resp["original-width"] == "0" {
+		resp["original-width"] = strconv.Itoa(original.Width)
+	}
+	if resp["original-height"] == "0" {
+		resp["original-height"] = strconv.Itoa(original.Height)
+	}
+
+	return resp
+}
+
+func (c crop) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The offsets and original image dimensions are public inputs, so the circuit only depends on the shapes,
+	// but they are checked to fail early.
+	if _, _, err := c.offsets(original, final, params); err != nil {
+		return nil, err
+	}
+
+	return &CropCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Cropped:    final.variables(),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (c crop) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	// The original image is unknown to verifiers, so the offsets are checked against the provided dimensions.
+	widthStartNew, heightStartNew, err := c.offsets(original.Shape(), final.Shape(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	shape, err := c.original(original.Shape(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CropCircuit{
+		Provenance:     provenance,
+		Original:       original.variables(),
+		Cropped:        final.variables(),
+		WidthStartNew:  widthStartNew,
+		HeightStartNew: heightStartNew,
+		OriginalWidth:  shape.Width,
+		OriginalHeight: shape.Height,
+		BitDepth:       final.Shape().BitDepth(),
+	}, nil
+}
+
+func (c crop) Apply(original Pixels, params Params) (Pixels, error) {
+	final, err := c.size(original.Shape(), params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	widthStartNew, heightStartNew, err := c.offsets(original.Shape(), final, params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(final)
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			copy(resp.Values[i][j], original.Values[i+heightStartNew][j+widthStartNew])
+		}
+	}
+
+	return resp, nil
+}
+
+// original returns the shape of the original image, either the provided dimensions or the shape of the original
+// image if they are zero, with its channels and bit depth. The dimensions must match the shape of the original image
+// if it is known. Verifiers resolve zero dimensions from the shape of the original image recorded by the prover,
+// see crop.Resolve.
+func (crop) original(original Shape, params Params) (Shape, error) {
+	width, err := params.Int("original-width")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	height, err := params.Int("original-height")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	if width == 0 {
+		width = original.Width
+	}
+	if height == 0 {
+		height = original.Height
+	}
+
+	if original == (Shape{}) && (width == 0 || height == 0) {
+		return Shape{}, errors.New("missing original image dimensions, provide original-width and original-height or the manifest of the proof")
+	}
+
+	if original != (Shape{}) && (width != original.Width || height != original.Height) {
+		return Shape{}, fmt.Errorf("original image %s does not match the provided size %dx%d", original, width, height)
+	}
+
+	resp := Shape{Width: width, Height: height, Alpha: original.Alpha, Depth16: original.Depth16}
+	if err = resp.validate(); err != nil {
+		return Shape{}, fmt.Errorf("original image, %w", err)
+	}
+
+	return resp, nil
+}
+
+// size returns the shape of the cropped image, defaulting to the remainder of the original image.
+func (crop) size(original Shape, params Params) (Shape, error) {
+	widthStartNew, err := params.Int("width-start-new")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	heightStartNew, err := params.Int("height-start-new")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	width, err := params.Int("width-new")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	height, err := params.Int("height-new")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	if width == 0 {
+		width = original.Width - widthStartNew
+	}
+	if height == 0 {
+		height = original.Height - heightStartNew
+	}
+
+	return Shape{Width: width, Height: height, Alpha: original.Alpha, Depth16: original.Depth16}, nil
+}
+
+// offsets returns the crop offsets, checking the cropped image lies within the original image. The original shape is
+// empty if the original image is unknown, in which case the provided dimensions are used.
+func (c crop) offsets(original, final Shape, params Params) (int, int, error) {
+	// Verifiers do not know the original image, so only its dimensions are checked, see crop.original.
+	if original != (Shape{}) && final.Alpha != original.Alpha {
+		return 0, 0, fmt.Errorf("cropped image %s does not match the channels of original image %s", final, original)
+	}
+	if original != (Shape{}) && final.Depth16 != original.Depth16 {
+		return 0, 0, fmt.Errorf("cropped image %s does not match the bit depth of original image %s", final, original)
+	}
+
+	original, err := c.original(original, params)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	widthStartNew, err := params.Int("width-start-new")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	heightStartNew, err := params.Int("height-start-new")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err = final.validate(); err != nil {
+		return 0, 0, err
+	}
+
+	width, err := params.Int("width-new")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	height, err := params.Int("height-new")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if (width != 0 && width != final.Width) || (height != 0 && height != final.Height) {
+		return 0, 0, fmt.Errorf("cropped image %s does not match the provided size %dx%d", final, width, height)
+	}
+
+	if widthStartNew < 0 || heightStartNew < 0 ||
+		widthStartNew+final.Width > original.Width || heightStartNew+final.Height > original.Height {
+		return 0, 0, fmt.Errorf("cropped image %s at offset (%d, %d) exceeds original image %s", final, widthStartNew, heightStartNew, original)
+	}
+
+	return widthStartNew, heightStartNew, nil
+}
+
+// CropCircuit represents the arithmetic circuit to prove crop transformations.
+// The offsets and the original image dimensions are public inputs, so the circuit only depends on the shapes.
+type CropCircuit struct {
+	Provenance     Provenance
+	Original       [][][]frontend.Variable `gnark:",secret"`
+	Cropped        [][][]frontend.Variable `gnark:",public"`
+	WidthStartNew  frontend.Variable       `gnark:",public"`
+	HeightStartNew frontend.Variable       `gnark:",public"`
+	OriginalWidth  frontend.Variable       `gnark:",public"`
+	OriginalHeight frontend.Variable       `gnark:",public"`
+	BitDepth       int
+}
+
+func (c *CropCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	height, width := len(c.Cropped), len(c.Cropped[0])
+
+	// The original image dimensions are defined by the circuit.
+	api.AssertIsEqual(c.OriginalHeight, len(c.Original))
+	api.AssertIsEqual(c.OriginalWidth, len(c.Original[0]))
+
+	// Select the cropped rows of the original image, then the cropped columns of every row.
+	rows := make([][]frontend.Variable, len(c.Original))
+	for i := range c.Original {
+		for j := range c.Original[i] {
+			rows[i] = append(rows[i], c.Original[i][j]...)
+		}
+	}
+	rows = selectWindow(api, c.HeightStartNew, rows, height)
+
+	channels := len(c.Original[0][0])
+	for i := range c.Cropped {
+		pixels := make([][]frontend.Variable, len(c.Original[0]))
+		for j := range pixels {
+			pixels[j] = rows[i][channels*j : channels*(j+1)]
+		}
+		pixels = selectWindow(api, c.WidthStartNew, pixels, width)
+
+		// The pixel values for the original and cropped images must match exactly, including the alpha channel.
+		for j := range c.Cropped[i] {
+			for k := range c.Cropped[i][j] {
+				api.AssertIsEqual(c.Cropped[i][j][k], pixels[j][k])
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectWindow returns the n values starting at the offset, asserting that the offset is in [0, len(values)-n].
+// The values are shifted by every bit of the offset in turn, so selecting costs a constraint per variable that can
+// still be selected and bit.
+func selectWindow(api frontend.API, offset frontend.Variable, values [][]frontend.Variable, n int) [][]frontend.Variable {
+	maxOffset := len(values) - n
+	if maxOffset == 0 {
+		api.AssertIsEqual(offset, 0)
+		return values
+	}
+
+	// Both the offset and maxOffset minus the offset have the bits of maxOffset, so the offset is in range.
+	nbBits := bits.Len(uint(maxOffset))
+	api.ToBinary(api.Sub(maxOffset, offset), nbBits)
+
+	for k, b := range api.ToBinary(offset, nbBits) {
+		// The values from n plus the remaining offset on can't be selected by any valid offset, as the remaining
+		// offset of the higher bits is at most maxOffset with the lower bits cleared.
+		shift := 1 << k
+		resp := make([][]frontend.Variable, n+maxOffset&^(2*shift-1))
+		for i := range resp {
+			if i+shift >= len(values) {
+				resp[i] = values[i]
+				continue
+			}
+
+			resp[i] = make([]frontend.Variable, len(values[i]))
+			for v := range resp[i] {
+				resp[i][v] = api.Select(b, values[i+shift][v], values[i][v])
+			}
+		}
+		values = resp
+	}
+
+	return values
+}
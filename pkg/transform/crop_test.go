@@ -0,0 +1,119 @@
+package transform
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCropResolve(t *testing.T) {
+	tr, err := Get("crop")
+	require.NoError(t, err)
+
+	resolver, ok := tr.(Resolver)
+	require.True(t, ok)
+
+	original, final := Shape{Width: 10, Height: 5}, Shape{Width: 2, Height: 2}
+
+	params := resolver.Resolve(original, final, Params{"width-start-new": "3"}.WithDefaults(tr))
+	require.Equal(t, "10", params["original-width"])
+	require.Equal(t, "5", params["original-height"])
+	require.Equal(t, "3", params["width-start-new"])
+
+	// Provided dimensions are kept, so they fail against the original image.
+	params = resolver.Resolve(original, final, Params{"original-width": "9"}.WithDefaults(tr))
+	require.Equal(t, "9", params["original-width"])
+
+	_, err = tr.Circuit(original, final, params, false)
+	require.ErrorContains(t, err, "original image 10x5 does not match the provided size 9x5")
+
+	// The original image keeps its channels and bit depth.
+	rgba16 := Shape{Width: 10, Height: 5, Alpha: true, Depth16: true}
+	shape, err := crop{}.original(rgba16, Params{}.WithDefaults(tr))
+	require.NoError(t, err)
+	require.Equal(t, rgba16, shape)
+
+	// Verifiers must provide the dimensions if they are not resolved from the shape recorded by the prover.
+	_, err = tr.Assignment(Pixels{}, NewPixels(final), Params{}.WithDefaults(tr), Provenance{})
+	require.ErrorContains(t, err, "missing original image dimensions")
+}
+
+func TestCropPublicOffsets(t *testing.T) {
+	tr, err := Get("crop")
+	require.NoError(t, err)
+
+	original := grayPixels([][]uint16{
+		{1, 2, 3, 4, 5},
+		{6, 7, 8, 9, 10},
+		{11, 12, 13, 14, 15},
+		{16, 17, 18, 19, 20},
+	})
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	// The circuit does not depend on the offsets, so the keys can be reused for any offset of the crop size.
+	cs, err := Compile(BackendGroth16, tr, original.Shape(), Shape{Width: 2, Height: 3}, Params{}.WithDefaults(tr), false)
+	require.NoError(t, err)
+
+	pk, vk, err := Setup(BackendGroth16, cs, nil)
+	require.NoError(t, err)
+
+	for _, offset := range [][2]string{{"0", "0"}, {"3", "1"}, {"2", "0"}, {"1", "1"}} {
+		params := Params{"width-start-new": offset[0], "height-start-new": offset[1], "width-new": "2", "height-new": "3"}.WithDefaults(tr)
+		params = tr.(Resolver).Resolve(original.Shape(), Shape{Width: 2, Height: 3}, params)
+
+		final, err := tr.Apply(original, params)
+		require.NoError(t, err)
+
+		proof, err := Prove(BackendGroth16, cs, pk, tr, original, final, params, provenance)
+		require.NoError(t, err)
+
+		// Verifiers only provide the public parameters.
+		public := Params{
+			"width-start-new":  params["width-start-new"],
+			"height-start-new": params["height-start-new"],
+			"original-width":   params["original-width"],
+			"original-height":  params["original-height"],
+		}.WithDefaults(tr)
+		require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, public, provenance))
+
+		// The verifier provides the offsets and original image dimensions claimed by the prover.
+		for name, value := range map[string]string{
+			"width-start-new":  "0",
+			"height-start-new": "2",
+			"original-width":   "6",
+			"original-height":  "5",
+		} {
+			if public[name] == value {
+				continue
+			}
+
+			invalid := Params{}.WithDefaults(tr)
+			for k, v := range public {
+				invalid[k] = v
+			}
+			invalid[name] = value
+
+			err = Verify(BackendGroth16, tr, proof, vk, final, invalid, provenance)
+			require.Error(t, err, name)
+		}
+	}
+
+	// Offsets beyond the original image must not satisfy the circuit.
+	circuit, err := tr.Circuit(original.Shape(), Shape{Width: 2, Height: 3}, Params{}.WithDefaults(tr), false)
+	require.NoError(t, err)
+
+	final := grayPixels([][]uint16{{4, 5}, {9, 10}, {14, 15}})
+
+	assignment, err := tr.Assignment(original, final, Params{"width-start-new": "3"}.WithDefaults(tr), provenance)
+	require.NoError(t, err)
+	assignment.(*CropCircuit).WidthStartNew = 4
+
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	require.Error(t, err)
+}
@@ -0,0 +1,310 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"math/big"
+)
+
+func init() {
+	Register(dihedral{})
+
+	// The rotations and flips are the dihedral transformation with a fixed symmetry.
+	Register(dihedralAlias{name: "rotate90", description: "Rotates the original image by 90 degrees clockwise."})
+	Register(dihedralAlias{name: "rotate180", description: "Rotates the original image by 180 degrees."})
+	Register(dihedralAlias{name: "rotate270", description: "Rotates the original image by 270 degrees clockwise."})
+	Register(dihedralAlias{name: "flip-horizontal", description: "Flips the original image horizontally."})
+	Register(dihedralAlias{name: "flip-vertical", description: "Flips the original image vertically."})
+}
+
+// symmetry is a symmetry of the square, the original image is transposed first and then flipped.
+type symmetry struct {
+	transpose      bool
+	flipVertical   bool
+	flipHorizontal bool
+}
+
+// symmetries are the 8 symmetries of the square by name.
+var symmetries = map[string]symmetry{
+	"identity":        {},
+	"rotate90":        {transpose: true, flipHorizontal: true},
+	"rotate180":       {flipVertical: true, flipHorizontal: true},
+	"rotate270":       {transpose: true, flipVertical: true},
+	"flip-horizontal": {flipHorizontal: true},
+	"flip-vertical":   {flipVertical: true},
+	"transpose":       {transpose: true},
+	"anti-transpose":  {transpose: true, flipVertical: true, flipHorizontal: true},
+}
+
+// shape returns the shape of the final image of an original image of the provided shape.
+func (s symmetry) shape(original Shape) Shape {
+	if s.transpose {
+		return original.Transposed()
+	}
+
+	return original
+}
+
+// source returns the row and column of the original pixel of the final pixel at row i and column j of the final
+// image of the provided shape.
+func (s symmetry) source(final Shape, i, j int) (int, int) {
+	if s.flipVertical {
+		i = final.Height - 1 - i
+	}
+	if s.flipHorizontal {
+		j = final.Width - 1 - j
+	}
+	if s.transpose {
+		return j, i
+	}
+
+	return i, j
+}
+
+// dihedral rotates the original image by a multiple of 90 degrees, flips or transposes it.
+type dihedral struct{}
+
+func (dihedral) Name() string {
+	return "dihedral"
+}
+
+func (dihedral) Description() string {
+	return "Rotates the original image by a multiple of 90 degrees, flips or transposes it."
+}
+
+func (dihedral) Params() []Param {
+	return []Param{
+		{
+			Name: "symmetry",
+			Usage: "The symmetry of the square to apply. Supported: identity, rotate90, rotate180, rotate270, flip-horizontal, " +
+				"flip-vertical, transpose and anti-transpose.",
+			Kind:    ParamString,
+			Default: "identity",
+			Public:  true,
+		},
+	}
+}
+
+func (d dihedral) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The symmetry is a public input, so the circuit only depends on whether it transposes non-square images,
+	// but it is checked to fail early.
+	s, err := d.symmetry(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return dihedralCircuit(original, final, s, "", signed)
+}
+
+func (d dihedral) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	s, err := d.symmetry(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return dihedralAssignment(original, final, s, provenance), nil
+}
+
+func (d dihedral) Apply(original Pixels, params Params) (Pixels, error) {
+	s, err := d.symmetry(params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	return dihedralApply(original, s)
+}
+
+// symmetry returns the symmetry of the parameters.
+func (dihedral) symmetry(params Params) (symmetry, error) {
+	s, ok := symmetries[params.String("symmetry")]
+	if !ok {
+		return symmetry{}, fmt.Errorf("unsupported symmetry, %s", params.String("symmetry"))
+	}
+
+	return s, nil
+}
+
+// dihedralAlias is the dihedral transformation with the symmetry of the same name compiled into the circuit.
+type dihedralAlias struct {
+	name        string
+	description string
+}
+
+func (a dihedralAlias) Name() string {
+	return a.name
+}
+
+func (a dihedralAlias) Description() string {
+	return a.description
+}
+
+func (dihedralAlias) Params() []Param {
+	return nil
+}
+
+func (a dihedralAlias) Circuit(original, final Shape, _ Params, signed bool) (frontend.Circuit, error) {
+	return dihedralCircuit(original, final, symmetries[a.name], a.name, signed)
+}
+
+func (a dihedralAlias) Assignment(original, final Pixels, _ Params, provenance Provenance) (frontend.Circuit, error) {
+	return dihedralAssignment(original, final, symmetries[a.name], provenance), nil
+}
+
+func (a dihedralAlias) Apply(original Pixels, _ Params) (Pixels, error) {
+	return dihedralApply(original, symmetries[a.name])
+}
+
+// dihedralCircuit returns the circuit definition of the symmetry, which is compiled into the circuit if fixed is its name.
+func dihedralCircuit(original, final Shape, s symmetry, fixed string, signed bool) (frontend.Circuit, error) {
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, s.shape(original)); err != nil {
+		return nil, err
+	}
+
+	return &DihedralCircuit{
+		Provenance:  provenanceCircuit(signed),
+		Original:    original.variables(),
+		Transformed: final.variables(),
+		Fixed:       fixed,
+		BitDepth:    original.BitDepth(),
+	}, nil
+}
+
+// dihedralAssignment returns the witness assignment of the symmetry.
+func dihedralAssignment(original, final Pixels, s symmetry, provenance Provenance) *DihedralCircuit {
+	return &DihedralCircuit{
+		Provenance:     provenance,
+		Original:       original.variables(),
+		Transformed:    final.variables(),
+		Transpose:      boolVariable(s.transpose),
+		FlipVertical:   boolVariable(s.flipVertical),
+		FlipHorizontal: boolVariable(s.flipHorizontal),
+		BitDepth:       final.Shape().BitDepth(),
+	}
+}
+
+// dihedralApply returns the final pixels of the symmetry of the original pixels.
+func dihedralApply(original Pixels, s symmetry) (Pixels, error) {
+	if err := original.Shape().validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(s.shape(original.Shape()))
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			row, col := s.source(resp.Shape(), i, j)
+			copy(resp.Values[i][j], original.Values[row][col])
+		}
+	}
+
+	return resp, nil
+}
+
+// boolVariable returns 1 if b is true, 0 otherwise.
+func boolVariable(b bool) frontend.Variable {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// DihedralCircuit represents the arithmetic circuit to prove dihedral transformations and its aliases.
+// The symmetry is a public input as the bits to transpose and then flip the original image, see symmetry.
+type DihedralCircuit struct {
+	Provenance     Provenance
+	Original       [][][]frontend.Variable `gnark:",secret"`
+	Transformed    [][][]frontend.Variable `gnark:",public"`
+	Transpose      frontend.Variable       `gnark:",public"`
+	FlipVertical   frontend.Variable       `gnark:",public"`
+	FlipHorizontal frontend.Variable       `gnark:",public"`
+	// Fixed is the name of the symmetry compiled into the circuit, or empty if it is selected by the public inputs.
+	Fixed    string
+	BitDepth int
+}
+
+func (c *DihedralCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	transpose, flipVertical, flipHorizontal := c.Transpose, c.FlipVertical, c.FlipHorizontal
+
+	// Constant bits select the pixels at compile time, so fixed symmetries cost no more than copying the pixels.
+	if c.Fixed != "" {
+		s := symmetries[c.Fixed]
+		transpose, flipVertical, flipHorizontal = boolVariable(s.transpose), boolVariable(s.flipVertical), boolVariable(s.flipHorizontal)
+	}
+
+	// The shapes of non-square images define whether they are transposed.
+	height, width := len(c.Original), len(c.Original[0])
+	if height != width {
+		transpose = boolVariable(len(c.Transformed) != height)
+	}
+
+	api.AssertIsEqual(c.Transpose, transpose)
+	api.AssertIsEqual(c.FlipVertical, flipVertical)
+	api.AssertIsEqual(c.FlipHorizontal, flipHorizontal)
+
+	// Transpose, then flip vertically and horizontally, selecting the pixels of every step by its bit.
+	pixels := selectPixels(api, transpose, c.Original, true, func(i, j int) (int, int) { return j, i })
+	height, width = len(pixels), len(pixels[0])
+	pixels = selectPixels(api, flipVertical, pixels, false, func(i, j int) (int, int) { return height - 1 - i, j })
+	pixels = selectPixels(api, flipHorizontal, pixels, false, func(i, j int) (int, int) { return i, width - 1 - j })
+
+	// The pixel values for the original and transformed images must match exactly, including the alpha channel.
+	for i := range c.Transformed {
+		for j := range c.Transformed[i] {
+			for k := range c.Transformed[i][j] {
+				api.AssertIsEqual(c.Transformed[i][j][k], pixels[i][j][k])
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectPixels returns the pixels mapped by source if the boolean b is 1, or the pixels if it is 0. The mapped pixel
+// at row i and column j is the pixel at source(i, j), transposed if source swaps its arguments. Pixels are only
+// transposed by a variable b if they are square.
+func selectPixels(api frontend.API, b frontend.Variable, pixels [][][]frontend.Variable, transposed bool, source func(i, j int) (int, int)) [][][]frontend.Variable {
+	// The mapped pixels of a constant b are selected at compile time. The bits of fixed symmetries and non-square
+	// images are Go integers, which are constant even if the compiler, e.g. the test engine, reports no constants.
+	v, constant := b.(int)
+	if !constant {
+		var c *big.Int
+		if c, constant = api.Compiler().ConstantValue(b); constant {
+			v = int(c.Int64())
+		}
+	}
+	if constant && v == 0 {
+		return pixels
+	}
+
+	height, width := len(pixels), len(pixels[0])
+	if transposed {
+		height, width = width, height
+	}
+
+	resp := make([][][]frontend.Variable, height)
+	for i := range resp {
+		resp[i] = make([][]frontend.Variable, width)
+		for j := range resp[i] {
+			row, col := source(i, j)
+			if constant || (row == i && col == j) {
+				resp[i][j] = pixels[row][col]
+				continue
+			}
+
+			resp[i][j] = make([]frontend.Variable, len(pixels[i][j]))
+			for k := range resp[i][j] {
+				resp[i][j][k] = api.Select(b, pixels[row][col][k], pixels[i][j][k])
+			}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,156 @@
+package transform
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDihedral(t *testing.T) {
+	tr, err := Get("dihedral")
+	require.NoError(t, err)
+
+	// 1 2 3
+	// 4 5 6
+	original := grayPixels([][]uint16{{1, 2, 3}, {4, 5, 6}})
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	tests := map[string][][]uint16{
+		"identity":        {{1, 2, 3}, {4, 5, 6}},
+		"rotate90":        {{4, 1}, {5, 2}, {6, 3}},
+		"rotate180":       {{6, 5, 4}, {3, 2, 1}},
+		"rotate270":       {{3, 6}, {2, 5}, {1, 4}},
+		"flip-horizontal": {{3, 2, 1}, {6, 5, 4}},
+		"flip-vertical":   {{4, 5, 6}, {1, 2, 3}},
+		"transpose":       {{1, 4}, {2, 5}, {3, 6}},
+		"anti-transpose":  {{6, 3}, {5, 2}, {4, 1}},
+	}
+	require.Len(t, tests, len(symmetries))
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			final, err := tr.Apply(original, Params{"symmetry": name})
+			require.NoError(t, err)
+			require.Equal(t, grayPixels(want), final)
+
+			// The circuit of the non-square image is satisfied by the symmetry.
+			params := Params{"symmetry": name}
+			circuit, err := tr.Circuit(original.Shape(), final.Shape(), params, false)
+			require.NoError(t, err)
+
+			assignment, err := tr.Assignment(original, final, params, provenance)
+			require.NoError(t, err)
+			require.NoError(t, test.IsSolved(circuit, assignment, ecc.BN254.ScalarField()))
+
+			// The rotations and flips are aliases of the dihedral transformation.
+			alias, err := Get(name)
+			if err != nil {
+				return
+			}
+
+			final, err = alias.Apply(original, nil)
+			require.NoError(t, err)
+			require.Equal(t, grayPixels(want), final)
+		})
+	}
+
+	_, err = tr.Apply(original, Params{"symmetry": "rotate45"})
+	require.ErrorContains(t, err, "unsupported symmetry")
+}
+
+func TestDihedralPublicSymmetry(t *testing.T) {
+	tr, err := Get("dihedral")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		original Pixels
+		symmetry string
+		invalid  []string
+	}{
+		{
+			// The symmetry of square images is selected by the public inputs.
+			name:     "square",
+			original: grayPixels([][]uint16{{1, 2}, {3, 4}}),
+			symmetry: "anti-transpose",
+			invalid:  []string{"identity", "transpose", "rotate90", "rotate270"},
+		},
+		{
+			// Non-square images are only transposed if the final image is.
+			name:     "non-square",
+			original: grayPixels([][]uint16{{1, 2, 3}, {4, 5, 6}}),
+			symmetry: "rotate180",
+			invalid:  []string{"flip-vertical", "flip-horizontal", "anti-transpose"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := Params{"symmetry": tt.symmetry}
+
+			final, err := tr.Apply(tt.original, params)
+			require.NoError(t, err)
+
+			// The circuit does not depend on the symmetry, so the keys can be reused for any symmetry of the shape.
+			cs, err := Compile(BackendGroth16, tr, tt.original.Shape(), final.Shape(), Params{}.WithDefaults(tr), false)
+			require.NoError(t, err)
+
+			pk, vk, err := Setup(BackendGroth16, cs, nil)
+			require.NoError(t, err)
+
+			hash, err := tt.original.Hash()
+			require.NoError(t, err)
+
+			provenance, err := NewProvenance(hash, nil, nil)
+			require.NoError(t, err)
+
+			proof, err := Prove(BackendGroth16, cs, pk, tr, tt.original, final, params, provenance)
+			require.NoError(t, err)
+			require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, params, provenance))
+
+			// The verifier provides the symmetry claimed by the prover.
+			for _, symmetry := range tt.invalid {
+				err = Verify(BackendGroth16, tr, proof, vk, final, Params{"symmetry": symmetry}, provenance)
+				require.Error(t, err, symmetry)
+			}
+		})
+	}
+}
+
+func TestDihedralAliasConstraints(t *testing.T) {
+	tr, err := Get("dihedral")
+	require.NoError(t, err)
+
+	shape := Shape{Width: 4, Height: 4}
+
+	cs, err := Compile(BackendGroth16, tr, shape, shape, Params{"symmetry": "rotate90"}, false)
+	require.NoError(t, err)
+
+	rotate90, err := Get("rotate90")
+	require.NoError(t, err)
+
+	alias, err := Compile(BackendGroth16, rotate90, shape, shape, nil, false)
+	require.NoError(t, err)
+
+	// Aliases select the pixels at compile time, while selecting them by the public inputs costs a constraint per
+	// channel of every moved pixel and step.
+	require.Less(t, alias.GetNbConstraints(), cs.GetNbConstraints()-3*shape.Width*shape.Height)
+}
+
+// grayPixels returns the gray pixels of the channel values.
+func grayPixels(values [][]uint16) Pixels {
+	resp := NewPixels(Shape{Width: len(values[0]), Height: len(values)})
+	for i := range values {
+		for j, v := range values[i] {
+			resp.Values[i][j] = []uint16{v, v, v}
+		}
+	}
+
+	return resp
+}
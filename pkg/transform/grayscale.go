@@ -0,0 +1,158 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+)
+
+func init() {
+	Register(grayscale{})
+}
+
+// lumaShift is the number of fractional bits of the integer luma weights.
+const lumaShift = 8
+
+// lumaWeights are the integer luma weights of the RGB channels by standard. The weights are the ITU-R
+// coefficients scaled by 2^lumaShift and rounded to the nearest integer, adjusted to sum to 2^lumaShift
+// so white stays white.
+var lumaWeights = map[string][3]int{
+	"bt601": {77, 150, 29}, // 0.299, 0.587, 0.114
+	"bt709": {54, 183, 19}, // 0.2126, 0.7152, 0.0722
+}
+
+// grayscale converts the original image to grayscale with the integer luma of a ITU-R standard.
+type grayscale struct{}
+
+func (grayscale) Name() string {
+	return "grayscale"
+}
+
+func (grayscale) Description() string {
+	return "Converts the original image to grayscale."
+}
+
+func (grayscale) Params() []Param {
+	return []Param{
+		{
+			Name:    "standard",
+			Usage:   "The ITU-R standard of the luma weights. Supported: bt601 and bt709.",
+			Kind:    ParamString,
+			Default: "bt601",
+		},
+	}
+}
+
+func (g grayscale) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	weights, err := g.weights(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &GrayscaleCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Grayscale:  final.variables(),
+		Weights:    weights,
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (g grayscale) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	weights, err := g.weights(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrayscaleCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Grayscale:  final.variables(),
+		Weights:    weights,
+		BitDepth:   final.Shape().BitDepth(),
+	}, nil
+}
+
+func (g grayscale) Apply(original Pixels, params Params) (Pixels, error) {
+	weights, err := g.weights(params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	if err := original.Shape().validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(original.Shape())
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			luma := uint16(lumaSum(weights, original.Values[i][j]) >> lumaShift)
+			resp.Values[i][j][0], resp.Values[i][j][1], resp.Values[i][j][2] = luma, luma, luma
+			copyAlpha(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// weights returns the luma weights of the standard parameter.
+func (grayscale) weights(params Params) ([3]int, error) {
+	standard := params.String("standard")
+
+	weights, ok := lumaWeights[standard]
+	if !ok {
+		return [3]int{}, fmt.Errorf("unsupported standard, %s", standard)
+	}
+
+	return weights, nil
+}
+
+// lumaSum returns the weighted sum of the RGB channels plus half of 2^lumaShift, so shifting it right by lumaShift
+// rounds the luma to the nearest integer, with halves rounded up. The luma is at most the maximum channel value as the
+// weights sum to 2^lumaShift.
+func lumaSum(weights [3]int, pixel []uint16) int {
+	return weights[0]*int(pixel[0]) + weights[1]*int(pixel[1]) + weights[2]*int(pixel[2]) + 1<<(lumaShift-1)
+}
+
+// GrayscaleCircuit represents the arithmetic circuit to prove grayscale transformations.
+type GrayscaleCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Grayscale  [][][]frontend.Variable `gnark:",public"`
+	Weights    [3]int
+	BitDepth   int
+}
+
+func (c *GrayscaleCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(c.Original); i++ {
+		for j := 0; j < len(c.Original[i]); j++ {
+			sum := api.Add(
+				api.Mul(c.Original[i][j][0], c.Weights[0]),
+				api.Mul(c.Original[i][j][1], c.Weights[1]),
+				api.Mul(c.Original[i][j][2], c.Weights[2]),
+				1<<(lumaShift-1),
+			)
+
+			// The luma is the weighted sum shifted right, see lumaSum.
+			luma := c.Grayscale[i][j][0]
+			assertDiv(api, sum, luma, 1<<lumaShift)
+
+			api.AssertIsEqual(c.Grayscale[i][j][1], luma) // G
+			api.AssertIsEqual(c.Grayscale[i][j][2], luma) // B
+			assertAlpha(api, c.Original[i][j], c.Grayscale[i][j])
+		}
+	}
+
+	return nil
+}
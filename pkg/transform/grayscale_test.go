@@ -0,0 +1,51 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGrayscale(t *testing.T) {
+	tr, err := Get("grayscale")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{
+		{0, 0, 0},
+		{255, 255, 255},
+		{255, 0, 0},
+		{0, 255, 0},
+		{0, 0, 255},
+		{10, 20, 30},
+		{2, 0, 0},
+	}}}
+
+	tests := []struct {
+		standard string
+		luma     []uint16
+	}{
+		{
+			// (77*r + 150*g + 29*b + 128) >> 8, e.g. (77*10 + 150*20 + 29*30 + 128) >> 8 = 4768 >> 8 = 18.
+			standard: "bt601",
+			luma:     []uint16{0, 255, 77, 149, 29, 18, 1},
+		},
+		{
+			// (54*r + 183*g + 19*b + 128) >> 8.
+			standard: "bt709",
+			luma:     []uint16{0, 255, 54, 182, 19, 19, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.standard, func(t *testing.T) {
+			final, err := tr.Apply(original, Params{"standard": tt.standard})
+			require.NoError(t, err)
+
+			for j, luma := range tt.luma {
+				require.Equal(t, []uint16{luma, luma, luma}, final.Values[0][j], "pixel %d", j)
+			}
+		})
+	}
+
+	_, err = tr.Apply(original, Params{"standard": "bt2020"})
+	require.ErrorContains(t, err, "unsupported standard")
+}
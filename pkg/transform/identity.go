@@ -0,0 +1,95 @@
+package transform
+
+import (
+	"github.com/consensys/gnark/frontend"
+)
+
+func init() {
+	Register(identity{name: "identity"})
+
+	// Re-encoding an image, e.g. to strip metadata or change its compression, must not change its pixels.
+	Register(identity{name: "reencode"})
+}
+
+// identity proves the final image has the same pixels as the original image, e.g. after re-encoding it.
+type identity struct {
+	name string
+}
+
+func (i identity) Name() string {
+	return i.name
+}
+
+func (identity) Description() string {
+	return "Proves the final image has the same pixels as the original image, e.g. after re-encoding it."
+}
+
+func (identity) Params() []Param {
+	return nil
+}
+
+func (identity) Circuit(original, final Shape, _ Params, signed bool) (frontend.Circuit, error) {
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &IdentityCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Final:      final.variables(),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (identity) Assignment(original, final Pixels, _ Params, provenance Provenance) (frontend.Circuit, error) {
+	return &IdentityCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Final:      final.variables(),
+		BitDepth:   final.Shape().BitDepth(),
+	}, nil
+}
+
+func (identity) Apply(original Pixels, _ Params) (Pixels, error) {
+	if err := original.Shape().validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(original.Shape())
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			copy(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// IdentityCircuit represents the arithmetic circuit to prove identity and reencode transformations.
+type IdentityCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Final      [][][]frontend.Variable `gnark:",public"`
+	BitDepth   int
+}
+
+func (c *IdentityCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	// The pixel values for the original and final images must match exactly, including the alpha channel.
+	for i := range c.Final {
+		for j := range c.Final[i] {
+			for k := range c.Final[i][j] {
+				api.AssertIsEqual(c.Final[i][j][k], c.Original[i][j][k])
+			}
+		}
+	}
+
+	return nil
+}
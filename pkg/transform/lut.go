@@ -0,0 +1,356 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(lut{})
+}
+
+// maxGamma is the maximum gamma of gamma lookup tables.
+const maxGamma = 10
+
+// lut maps every channel of the original image with a per-channel lookup table, e.g. a tone curve or gamma.
+type lut struct{}
+
+func (lut) Name() string {
+	return "lut"
+}
+
+func (lut) Description() string {
+	return "Maps the channels of the original image with a lookup table, e.g. a tone curve or gamma."
+}
+
+func (lut) Params() []Param {
+	return []Param{
+		{
+			Name: "lut",
+			Usage: "The path to the lookup table file of 256 lines, the final value of every original channel value from 0 to 255, " +
+				"either one value for all channels or three space separated values for red, green and blue. " +
+				"16-bit images need 65536 lines, from 0 to 65535.",
+			Kind:     ParamString,
+			Public:   true,
+			Optional: true,
+		},
+		{
+			Name: "table-hash",
+			Usage: "The hash of the lookup table printed by prove, instead of a lookup table file or gamma when verifying. " +
+				"Set by prove from the lookup table file, so proofs record the hash instead of the path. " +
+				"If a lookup table file or gamma is provided too, its table must have the hash.",
+			Kind:     ParamString,
+			Public:   true,
+			Optional: true,
+		},
+		{
+			Name:     "gamma",
+			Usage:    "The gamma in (0, 10] of a gamma correction lookup table, instead of a lookup table file. Gammas above 1 brighten the image.",
+			Kind:     ParamFloat,
+			Public:   true,
+			Optional: true,
+		},
+	}
+}
+
+// Resolve replaces the path of the lookup table file with the hash of the table, so proofs are verified without the
+// file. Invalid files, and files that don't match a provided hash, are kept, so the error is returned by the circuit.
+func (l lut) Resolve(original, _ Shape, params Params) Params {
+	resp := params.WithDefaults(l)
+	if resp["lut"] == "" {
+		return resp
+	}
+
+	_, hash, err := l.table(resp, original.BitDepth())
+	if err != nil {
+		return resp
+	}
+
+	resp["lut"], resp["table-hash"] = "", FormatHash(hash)
+
+	return resp
+}
+
+func (l lut) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The table hash is a public input, so the circuit does not depend on the table, but it is checked to fail early.
+	if _, _, err := l.table(params, original.BitDepth()); err != nil {
+		return nil, err
+	}
+
+	if err := original.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	return &LUTCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Mapped:     final.variables(),
+		Table:      Shape{Width: original.MaxValue() + 1, Height: 1}.variables(),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (l lut) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	bitDepth := final.Shape().BitDepth()
+
+	table, hash, err := l.table(params, bitDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verifiers only provide the table hash, the table itself is secret.
+	var tablePixels Pixels
+	if original.Values != nil {
+		if table[0] == nil {
+			return nil, errMissingTable
+		}
+
+		tablePixels = lutPixels(table, bitDepth)
+	}
+
+	return &LUTCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Mapped:     final.variables(),
+		Table:      tablePixels.variables(),
+		TableHash:  hash,
+		BitDepth:   bitDepth,
+	}, nil
+}
+
+func (l lut) Apply(original Pixels, params Params) (Pixels, error) {
+	table, _, err := l.table(params, original.Shape().BitDepth())
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	if table[0] == nil {
+		return Pixels{}, errMissingTable
+	}
+
+	if err := original.Shape().validate(); err != nil {
+		return Pixels{}, err
+	}
+
+	resp := NewPixels(original.Shape())
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			for k := 0; k < 3; k++ {
+				resp.Values[i][j][k] = table[k][original.Values[i][j][k]]
+			}
+			copyAlpha(resp.Values[i][j], original.Values[i][j])
+		}
+	}
+
+	return resp, nil
+}
+
+// errMissingTable is returned if only the table hash is provided, but the table itself is required.
+var errMissingTable = errors.New("missing lut file or gamma, the table hash only verifies proofs")
+
+// table returns the lookup table of every channel value of the bit depth, either read from the lut file or computed
+// from the gamma, and its hash, see lutPixels. If only the table hash is provided, the table is empty.
+func (lut) table(params Params, bitDepth int) ([3][]uint16, *big.Int, error) {
+	path, gamma, tableHash := params.String("lut"), params.String("gamma"), params.String("table-hash")
+
+	var (
+		table [3][]uint16
+		err   error
+	)
+	switch {
+	case path != "" && gamma != "":
+		return table, nil, errors.New("both lut file and gamma provided")
+	case path != "":
+		table, err = readLUT(path, bitDepth)
+		if err != nil {
+			return table, nil, err
+		}
+	case gamma != "":
+		g, err := params.Float("gamma")
+		if err != nil {
+			return table, nil, err
+		}
+
+		if g <= 0 || g > maxGamma {
+			return table, nil, fmt.Errorf("gamma %v out of range (0, %d]", g, maxGamma)
+		}
+
+		table = gammaLUT(g, bitDepth)
+	case tableHash == "":
+		return table, nil, errors.New("missing lut file, gamma or table hash")
+	}
+
+	var want *big.Int
+	if tableHash != "" {
+		if want, err = ParseHash(tableHash); err != nil {
+			return table, nil, fmt.Errorf("invalid table-hash parameter, %w", err)
+		}
+	}
+
+	if table[0] == nil {
+		return table, want, nil
+	}
+
+	hash, err := lutPixels(table, bitDepth).Hash()
+	if err != nil {
+		return table, nil, err
+	}
+
+	if want != nil && want.Cmp(hash) != 0 {
+		return table, nil, fmt.Errorf("lookup table hash %s does not match the table hash %s", FormatHash(hash), tableHash)
+	}
+
+	return table, hash, nil
+}
+
+// lutPixels returns the lookup table as a row of pixels of the bit depth, the final red, green and blue values of
+// every original channel value, so it is hashed like an image, see Pixels.Hash.
+func lutPixels(table [3][]uint16, bitDepth int) Pixels {
+	row := make([][]uint16, len(table[0]))
+	for v := range row {
+		row[v] = []uint16{table[0][v], table[1][v], table[2][v]}
+	}
+
+	return Pixels{Values: [][][]uint16{row}, Depth16: bitDepth == 16}
+}
+
+// newLUT returns an empty lookup table of every channel value of the bit depth.
+func newLUT(bitDepth int) [3][]uint16 {
+	var resp [3][]uint16
+	for k := range resp {
+		resp[k] = make([]uint16, maxValue(bitDepth)+1)
+	}
+
+	return resp
+}
+
+// gammaLUT returns the lookup table of the gamma correction max * (v/max)^(1/gamma) of every channel value v of the
+// bit depth, rounded to the nearest integer.
+func gammaLUT(gamma float64, bitDepth int) [3][]uint16 {
+	resp := newLUT(bitDepth)
+	hi := float64(maxValue(bitDepth))
+	for v := range resp[0] {
+		mapped := uint16(math.Round(hi * math.Pow(float64(v)/hi, 1/gamma)))
+		resp[0][v], resp[1][v], resp[2][v] = mapped, mapped, mapped
+	}
+
+	return resp
+}
+
+// readLUT returns the lookup table of every channel value of the bit depth in the lut file, see parseLUT.
+func readLUT(path string, bitDepth int) ([3][]uint16, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return newLUT(bitDepth), fmt.Errorf("read lut file, %w", err)
+	}
+
+	return parseLUT("lut file", strings.Split(string(b), "\n"), bitDepth)
+}
+
+// parseLUT returns the lookup table of every channel value of the bit depth in the lines of the named lut. Every
+// non-empty line that isn't a # comment is the final value of the next original channel value, either one value for
+// all channels or one per channel.
+func parseLUT(name string, lines []string, bitDepth int) ([3][]uint16, error) {
+	resp, hi := newLUT(bitDepth), maxValue(bitDepth)
+
+	var v int
+	for n, line := range lines {
+		line, _, _ = strings.Cut(line, "#")
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if v > hi {
+			return resp, fmt.Errorf("%s has more than %d values, line %d", name, hi+1, n+1)
+		}
+
+		if len(fields) != 1 && len(fields) != 3 {
+			return resp, fmt.Errorf("invalid %s line %d, expected one or three values", name, n+1)
+		}
+
+		for k := 0; k < 3; k++ {
+			mapped, err := strconv.ParseUint(fields[k%len(fields)], 10, bitDepth)
+			if err != nil {
+				return resp, fmt.Errorf("invalid %s value, line %d, %w", name, n+1, err)
+			}
+
+			resp[k][v] = uint16(mapped)
+		}
+		v++
+	}
+
+	if v != hi+1 {
+		return resp, fmt.Errorf("%s has %d values, expected %d", name, v, hi+1)
+	}
+
+	return resp, nil
+}
+
+// LUTCircuit represents the arithmetic circuit to prove lut transformations.
+// The lookup table of every channel value of the bit depth is secret, see lutPixels, and its hash is a public input,
+// so the public inputs don't grow with the table.
+type LUTCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Mapped     [][][]frontend.Variable `gnark:",public"`
+	Table      [][][]frontend.Variable `gnark:",secret"`
+	TableHash  frontend.Variable       `gnark:",public"`
+	BitDepth   int
+}
+
+func (c *LUTCircuit) Define(api frontend.API) error {
+	// The provenance range checks the original channel values, so they are valid table indexes.
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	// The hash range checks the table entries, so it commits to a unique table.
+	if err := assertPixelsHash(api, c.Table, c.BitDepth, c.TableHash); err != nil {
+		return err
+	}
+
+	// Every final channel value must be the table entry of the original channel value, checked with a
+	// log-derivative lookup argument per channel.
+	for k := 0; k < 3; k++ {
+		table := logderivlookup.New(api)
+		for _, v := range c.Table[0] {
+			table.Insert(v[k])
+		}
+
+		var indexes []frontend.Variable
+		for i := range c.Original {
+			for j := range c.Original[i] {
+				indexes = append(indexes, c.Original[i][j][k])
+			}
+		}
+
+		values := table.Lookup(indexes...)
+		for i := range c.Mapped {
+			for j := range c.Mapped[i] {
+				api.AssertIsEqual(c.Mapped[i][j][k], values[i*len(c.Mapped[i])+j])
+			}
+		}
+	}
+
+	// The table does not map the alpha channel.
+	for i := range c.Mapped {
+		for j := range c.Mapped[i] {
+			assertAlpha(api, c.Original[i][j], c.Mapped[i][j])
+		}
+	}
+
+	return nil
+}
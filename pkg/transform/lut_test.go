@@ -0,0 +1,201 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLUT(t *testing.T) {
+	tr, err := Get("lut")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 64, 128}, {255, 1, 2}}}}
+
+	dir := t.TempDir()
+
+	// An inverting table with one value per line and comments.
+	var gray strings.Builder
+	gray.WriteString("# invert\n\n")
+	for v := 0; v <= MaxPixelValue; v++ {
+		fmt.Fprintf(&gray, "%d # %d\n", MaxPixelValue-v, v)
+	}
+	grayFile := filepath.Join(dir, "gray.lut")
+	require.NoError(t, os.WriteFile(grayFile, []byte(gray.String()), 0o644))
+
+	// A table that keeps red, doubles green and zeroes blue.
+	var rgb strings.Builder
+	for v := 0; v <= MaxPixelValue; v++ {
+		fmt.Fprintf(&rgb, "%d %d 0\n", v, min(2*v, MaxPixelValue))
+	}
+	rgbFile := filepath.Join(dir, "rgb.lut")
+	require.NoError(t, os.WriteFile(rgbFile, []byte(rgb.String()), 0o644))
+
+	tests := []struct {
+		name   string
+		params Params
+		final  Pixels
+	}{
+		{
+			name:   "gamma 1",
+			params: Params{"gamma": "1"},
+			final:  original,
+		},
+		{
+			// 255 * (64/255)^(1/2.2) = 136.4 and 255 * (128/255)^(1/2.2) = 186.3.
+			name:   "gamma 2.2",
+			params: Params{"gamma": "2.2"},
+			final:  Pixels{Values: [][][]uint16{{{0, 136, 186}, {255, 21, 28}}}},
+		},
+		{
+			name:   "lut file",
+			params: Params{"lut": grayFile},
+			final:  Pixels{Values: [][][]uint16{{{255, 191, 127}, {0, 254, 253}}}},
+		},
+		{
+			name:   "lut file per channel",
+			params: Params{"lut": rgbFile},
+			final:  Pixels{Values: [][][]uint16{{{0, 128, 0}, {255, 2, 0}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := tr.Apply(original, tt.params.WithDefaults(tr))
+			require.NoError(t, err)
+			require.Equal(t, tt.final, final)
+		})
+	}
+
+	var n int
+	invalid := func(content string) string {
+		n++
+		path := filepath.Join(dir, fmt.Sprintf("invalid%d.lut", n))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		return path
+	}
+
+	for _, tt := range []struct {
+		params Params
+		err    string
+	}{
+		{params: Params{}, err: "missing lut file, gamma or table hash"},
+		{params: Params{"lut": grayFile, "gamma": "2"}, err: "both lut file and gamma provided"},
+		{params: Params{"table-hash": "0x1"}, err: "missing lut file or gamma"},
+		{params: Params{"table-hash": "0x1", "gamma": "2"}, err: "does not match the table hash 0x1"},
+		{params: Params{"table-hash": "gamma"}, err: "invalid table-hash parameter"},
+		{params: Params{"gamma": "0"}, err: "out of range"},
+		{params: Params{"gamma": "11"}, err: "out of range"},
+		{params: Params{"lut": filepath.Join(dir, "missing.lut")}, err: "read lut file"},
+		{params: Params{"lut": invalid("1\n2\n")}, err: "lut file has 2 values"},
+		{params: Params{"lut": invalid(gray.String() + "0\n")}, err: "lut file has more than 256 values"},
+		{params: Params{"lut": invalid("1 2\n")}, err: "expected one or three values"},
+		{params: Params{"lut": invalid("256\n")}, err: "invalid lut file value"},
+	} {
+		_, err = tr.Apply(original, tt.params.WithDefaults(tr))
+		require.ErrorContains(t, err, tt.err)
+	}
+}
+
+func TestLUTResolve(t *testing.T) {
+	tr, err := Get("lut")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 64, 128}, {255, 1, 2}}}}
+
+	var rgb strings.Builder
+	rgb.WriteString("# red green blue\n")
+	for v := 0; v <= MaxPixelValue; v++ {
+		fmt.Fprintf(&rgb, "%d %d %d\n", v, min(2*v, MaxPixelValue), v)
+	}
+	path := filepath.Join(t.TempDir(), "rgb.lut")
+	require.NoError(t, os.WriteFile(path, []byte(rgb.String()), 0o644))
+
+	// The table is hashed like a row of pixels with the final values of every original value.
+	table, hash, err := lut{}.table(Params{"lut": path}, 8)
+	require.NoError(t, err)
+	require.Equal(t, []uint16{1, 2, 1}, lutPixels(table, 8).Values[0][1])
+
+	want, err := lutPixels(table, 8).Hash()
+	require.NoError(t, err)
+	require.Equal(t, want, hash)
+
+	// Provers record the table hash instead of the path of the file, so verifiers don't need it.
+	params := tr.(Resolver).Resolve(original.Shape(), original.Shape(), Params{"lut": path})
+	require.Empty(t, params["lut"])
+	require.Equal(t, FormatHash(hash), params["table-hash"])
+
+	// Verifiers providing the file as well check it against the hash.
+	params["lut"] = path
+	_, err = tr.Apply(original, params)
+	require.NoError(t, err)
+
+	params["lut"], params["gamma"] = "", "2.2"
+	_, err = tr.Apply(original, params)
+	require.ErrorContains(t, err, "does not match the table hash")
+
+	// Files that don't match the provided hash are kept, so the error is returned later.
+	params = tr.(Resolver).Resolve(original.Shape(), original.Shape(), Params{"lut": path, "table-hash": "0x1"})
+	require.Equal(t, path, params["lut"])
+
+	// Invalid files are kept, so the error is returned later.
+	params = tr.(Resolver).Resolve(original.Shape(), original.Shape(), Params{"lut": filepath.Join(t.TempDir(), "missing.lut")})
+	_, err = tr.Apply(original, params)
+	require.ErrorContains(t, err, "read lut file")
+}
+
+func TestLUTTableHash(t *testing.T) {
+	tr, err := Get("lut")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{{{0, 64, 128}, {255, 1, 2}}}}
+	params := Params{"gamma": "2.2"}.WithDefaults(tr)
+
+	final, err := tr.Apply(original, params)
+	require.NoError(t, err)
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	for _, backend := range []string{BackendGroth16, BackendPlonk} {
+		t.Run(backend, func(t *testing.T) {
+			// The circuit only depends on the bit depth of the table, so the keys can be reused for any table.
+			cs, err := Compile(backend, tr, original.Shape(), final.Shape(), Params{"gamma": "1"}.WithDefaults(tr), false)
+			require.NoError(t, err)
+
+			srs, err := NewInsecureSRS(SRSSize(cs))
+			require.NoError(t, err)
+
+			pk, vk, err := Setup(backend, cs, srs)
+			require.NoError(t, err)
+
+			proof, err := Prove(backend, cs, pk, tr, original, final, params, provenance)
+			require.NoError(t, err)
+			require.NoError(t, Verify(backend, tr, proof, vk, final, params, provenance))
+
+			// Verifiers may only provide the table hash, which the proof binds.
+			_, hash, err := lut{}.table(params, 8)
+			require.NoError(t, err)
+
+			err = Verify(backend, tr, proof, vk, final, Params{"table-hash": FormatHash(hash)}.WithDefaults(tr), provenance)
+			require.NoError(t, err)
+
+			err = Verify(backend, tr, proof, vk, final, Params{"gamma": "2.3"}.WithDefaults(tr), provenance)
+			require.Error(t, err)
+		})
+	}
+
+	// The lookup argument commits to the lookups, which the ceremony does not support.
+	cs, err := Compile(BackendGroth16, tr, original.Shape(), final.Shape(), params, false)
+	require.NoError(t, err)
+
+	_, err = InitPhase2(cs, nil)
+	require.ErrorContains(t, err, "ceremony does not support circuits with commitments")
+}
@@ -0,0 +1,317 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"image"
+	"image/color"
+)
+
+// Pixels holds the RGB or RGBA pixel values of an image with their bit depth.
+type Pixels struct {
+	// Values are the channel values indexed by row, column and channel. The alpha channel, if any, is not
+	// premultiplied.
+	Values [][][]uint16
+	// Depth16 is true if the channel values have 16 bits, 8 otherwise, see Shape.Depth16.
+	Depth16 bool
+}
+
+// Shape describes the dimensions of an image.
+type Shape struct {
+	Width  int
+	Height int
+	// Alpha is true if the pixels have an alpha channel, see FromImage.
+	Alpha bool `json:",omitempty"`
+	// Depth16 is true if the channel values have 16 bits instead of 8, see FromImage.
+	Depth16 bool `json:",omitempty"`
+}
+
+// String returns the shape formatted as width x height, suffixed with RGBA if the pixels have an alpha channel and
+// with the bit depth if it is 16.
+func (s Shape) String() string {
+	resp := fmt.Sprintf("%dx%d", s.Width, s.Height)
+	if s.Alpha {
+		resp += " RGBA"
+	}
+	if s.Depth16 {
+		resp += " 16-bit"
+	}
+
+	return resp
+}
+
+// Transposed returns the shape with width and height swapped.
+func (s Shape) Transposed() Shape {
+	return Shape{Width: s.Height, Height: s.Width, Alpha: s.Alpha, Depth16: s.Depth16}
+}
+
+// BitDepth returns the number of bits of every channel value, 16 or 8.
+func (s Shape) BitDepth() int {
+	if s.Depth16 {
+		return 16
+	}
+
+	return 8
+}
+
+// MaxValue returns the maximum channel value, 65535 for 16-bit channels, MaxPixelValue otherwise.
+func (s Shape) MaxValue() int {
+	return maxValue(s.BitDepth())
+}
+
+// maxValue returns the maximum channel value of the bit depth.
+func maxValue(bitDepth int) int {
+	return 1<<bitDepth - 1
+}
+
+// depthScale returns the factor from 8-bit channel values to channel values of the bit depth, 257 for 16 bits, so
+// parameters in 8-bit channel values, e.g. offsets and colors, span the same range at both bit depths.
+func depthScale(bitDepth int) int {
+	return maxValue(bitDepth) / MaxPixelValue
+}
+
+// Channels returns the number of channels of every pixel, 4 if the pixels have an alpha channel, 3 otherwise.
+func (s Shape) Channels() int {
+	if s.Alpha {
+		return 4
+	}
+
+	return 3
+}
+
+// variables returns placeholder circuit variables of the shape.
+func (s Shape) variables() [][][]frontend.Variable {
+	resp := make([][][]frontend.Variable, s.Height) // First dimension
+	for i := range resp {
+		resp[i] = make([][]frontend.Variable, s.Width) // Second dimension
+		for j := range resp[i] {
+			resp[i][j] = make([]frontend.Variable, s.Channels()) // Third dimension
+		}
+	}
+
+	return resp
+}
+
+// validate returns an error if the shape has no pixels.
+func (s Shape) validate() error {
+	if s.Width <= 0 || s.Height <= 0 {
+		return errors.New("empty image")
+	}
+
+	return nil
+}
+
+// expectShape returns an error if the final shape doesn't match the shape expected by the transformation.
+func expectShape(final, want Shape) error {
+	if final != want {
+		return fmt.Errorf("final image %s does not match expected %s", final, want)
+	}
+
+	return nil
+}
+
+// FromImage returns the pixel values of the image. The pixels have an alpha channel if the image is not opaque,
+// with the color channels converted from the premultiplied image colors, see color.NRGBAModel. The channel values have
+// 16 bits if the image does, e.g. 16-bit PNG or TIFF images, 8 bits otherwise.
+func FromImage(img image.Image) Pixels {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	alpha := !opaque(img)
+
+	pixels := Pixels{Values: make([][][]uint16, height), Depth16: BitDepth(img) == 16} // height x width x rgb(a)
+	for y := 0; y < height; y++ {
+		pixels.Values[y] = make([][]uint16, width)
+		for x := 0; x < width; x++ {
+			c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+
+			// The 8-bit non-premultiplied colors are converted directly, so colors of nearly transparent pixels
+			// are not lost to rounding the 16-bit colors, e.g. NRGBA images are read unchanged.
+			switch {
+			case alpha && pixels.Depth16:
+				n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+				pixels.Values[y][x] = []uint16{n.R, n.G, n.B, n.A}
+			case alpha:
+				n := color.NRGBAModel.Convert(c).(color.NRGBA)
+				pixels.Values[y][x] = []uint16{uint16(n.R), uint16(n.G), uint16(n.B), uint16(n.A)}
+			case pixels.Depth16:
+				r, g, b, _ := c.RGBA()
+				pixels.Values[y][x] = []uint16{uint16(r), uint16(g), uint16(b)}
+			default:
+				// Shift color values by 8 bits to scale from 0-65535 to 0-255 for 8-bit images
+				r, g, b, _ := c.RGBA()
+				pixels.Values[y][x] = []uint16{uint16(r >> 8), uint16(g >> 8), uint16(b >> 8)}
+			}
+		}
+	}
+
+	return pixels
+}
+
+// BitDepth returns the number of bits of the channel values of the image, 16 for 16-bit image types, e.g. decoded
+// from 16-bit PNG or TIFF images, 8 otherwise.
+func BitDepth(img image.Image) int {
+	switch img.(type) {
+	case *image.RGBA64, *image.NRGBA64, *image.Gray16:
+		return 16
+	default:
+		return 8
+	}
+}
+
+// opaque returns true if every pixel of the image is fully opaque.
+func opaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// NewPixels returns opaque black pixels of the provided shape.
+func NewPixels(shape Shape) Pixels {
+	pixels := Pixels{Values: make([][][]uint16, shape.Height), Depth16: shape.Depth16}
+	for y := range pixels.Values {
+		pixels.Values[y] = make([][]uint16, shape.Width)
+		for x := range pixels.Values[y] {
+			pixels.Values[y][x] = make([]uint16, shape.Channels())
+			if shape.Alpha {
+				pixels.Values[y][x][3] = uint16(shape.MaxValue())
+			}
+		}
+	}
+
+	return pixels
+}
+
+// Shape returns the shape of the pixels.
+func (p Pixels) Shape() Shape {
+	if len(p.Values) == 0 {
+		return Shape{}
+	}
+
+	return Shape{
+		Width:   len(p.Values[0]),
+		Height:  len(p.Values),
+		Alpha:   len(p.Values[0]) > 0 && len(p.Values[0][0]) == 4,
+		Depth16: p.Depth16,
+	}
+}
+
+// Image returns an RGBA image of the pixels, which is opaque if the pixels have no alpha channel and has 16-bit
+// channels if the pixels do.
+func (p Pixels) Image() image.Image {
+	shape := p.Shape()
+	rect := image.Rect(0, 0, shape.Width, shape.Height)
+	if shape.Depth16 {
+		img := image.NewNRGBA64(rect)
+		for y, row := range p.Values {
+			for x, v := range row {
+				c := color.NRGBA64{R: v[0], G: v[1], B: v[2], A: 0xffff}
+				if len(v) == 4 {
+					c.A = v[3]
+				}
+				img.SetNRGBA64(x, y, c)
+			}
+		}
+
+		return img
+	}
+
+	img := image.NewNRGBA(rect)
+	for y, row := range p.Values {
+		for x, v := range row {
+			c := color.NRGBA{R: uint8(v[0]), G: uint8(v[1]), B: uint8(v[2]), A: MaxPixelValue}
+			if len(v) == 4 {
+				c.A = uint8(v[3])
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+// WithAlpha returns the pixels with an alpha channel, adding an opaque one if they have none. Transformations of
+// images with an alpha channel are proven on RGBA pixels, so opaque final images, e.g. crops of opaque regions, are
+// compared with an opaque alpha channel.
+func (p Pixels) WithAlpha() Pixels {
+	shape := p.Shape()
+	if shape.Alpha {
+		return p
+	}
+
+	shape.Alpha = true
+	resp := NewPixels(shape)
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			copy(resp.Values[i][j], p.Values[i][j])
+		}
+	}
+
+	return resp
+}
+
+// WithDepth16 returns the pixels with 16-bit channel values, multiplying 8-bit channel values by 257 so their maximum
+// is the 16-bit maximum. Original images with 16-bit channels are proven on 16-bit pixels, so final images with 8-bit
+// channels are compared at full precision.
+func (p Pixels) WithDepth16() Pixels {
+	shape := p.Shape()
+	if shape.Depth16 {
+		return p
+	}
+
+	shape.Depth16 = true
+	resp := NewPixels(shape)
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			for k := range resp.Values[i][j] {
+				resp.Values[i][j][k] = p.Values[i][j][k] * uint16(depthScale(16))
+			}
+		}
+	}
+
+	return resp
+}
+
+// copyAlpha copies the alpha channel of the original pixel, if any, to the final pixel. Color transformations keep
+// the alpha channel, see assertAlpha.
+func copyAlpha(final, original []uint16) {
+	copy(final[3:], original[3:])
+}
+
+// assertAlpha asserts that the final pixel has the alpha channel of the original pixel, if any.
+func assertAlpha(api frontend.API, original, final []frontend.Variable) {
+	for k := 3; k < len(final); k++ {
+		api.AssertIsEqual(final[k], original[k])
+	}
+}
+
+// variables returns the pixel values as circuit variables, or nil if there are no pixels.
+func (p Pixels) variables() [][][]frontend.Variable {
+	if p.Values == nil {
+		return nil
+	}
+
+	resp := make([][][]frontend.Variable, len(p.Values)) // First dimension
+	for i := range p.Values {
+		resp[i] = make([][]frontend.Variable, len(p.Values[i])) // Second dimension
+		for j := range p.Values[i] {
+			resp[i][j] = make([]frontend.Variable, len(p.Values[i][j])) // Third dimension
+			for k := range p.Values[i][j] {
+				resp[i][j][k] = frontend.Variable(p.Values[i][j][k])
+			}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,389 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/cmp"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(redact{})
+}
+
+const (
+	FillSolid    = "solid"
+	FillPixelate = "pixelate"
+)
+
+// redact fills rectangular regions of the original image with a solid color or pixelates them, leaving the other
+// pixels unchanged.
+type redact struct{}
+
+func (redact) Name() string {
+	return "redact"
+}
+
+func (redact) Description() string {
+	return "Redacts regions of the original image."
+}
+
+func (redact) Params() []Param {
+	return []Param{
+		{
+			Name:   "regions",
+			Usage:  "The comma separated rectangles to redact as WIDTHxHEIGHT+X+Y, e.g. 20x10+5+5 for the 20x10 rectangle with top-left corner (5, 5).",
+			Kind:   ParamString,
+			Public: true,
+		},
+		{
+			Name:    "fill",
+			Usage:   "The fill of the redacted regions. Supported: solid and pixelate.",
+			Kind:    ParamString,
+			Default: FillSolid,
+		},
+		{
+			Name:    "color",
+			Usage:   "The hex encoded RGB color of the solid fill, e.g. ff0000 for red, multiplied by 257 for 16-bit images.",
+			Kind:    ParamString,
+			Default: "000000",
+		},
+		{
+			Name:    "block-size",
+			Usage:   "The size of the pixelate fill blocks. Blocks are aligned to the top-left corner of the original image.",
+			Kind:    ParamInt,
+			Default: "8",
+		},
+	}
+}
+
+func (r redact) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	// The regions are public inputs, so the circuit only depends on their number, but they are checked to fail early.
+	regions, err := r.regions(original, params)
+	if err != nil {
+		return nil, err
+	}
+
+	fill, err := r.fill(params, original.BitDepth())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, original); err != nil {
+		return nil, err
+	}
+
+	var blocks [][][]frontend.Variable
+	if fill.mode == FillPixelate {
+		blocks = fill.blocks(original).variables()
+	}
+
+	return &RedactCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Redacted:   final.variables(),
+		Regions:    make([][4]frontend.Variable, len(regions)),
+		Blocks:     blocks,
+		Fill:       fill.mode,
+		Color:      fill.color,
+		BlockSize:  fill.blockSize,
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (r redact) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	regions, err := r.regions(final.Shape(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	bitDepth := final.Shape().BitDepth()
+
+	fill, err := r.fill(params, bitDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks [][][]frontend.Variable
+	if fill.mode == FillPixelate && original.Values != nil {
+		blocks = fill.averages(original).variables()
+	}
+
+	assignment := &RedactCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Redacted:   final.variables(),
+		Regions:    make([][4]frontend.Variable, len(regions)),
+		Blocks:     blocks,
+		Fill:       fill.mode,
+		Color:      fill.color,
+		BlockSize:  fill.blockSize,
+		BitDepth:   bitDepth,
+	}
+	for i, region := range regions {
+		assignment.Regions[i] = [4]frontend.Variable{region.x, region.y, region.width, region.height}
+	}
+
+	return assignment, nil
+}
+
+func (r redact) Apply(original Pixels, params Params) (Pixels, error) {
+	regions, err := r.regions(original.Shape(), params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	fill, err := r.fill(params, original.Shape().BitDepth())
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	var blocks Pixels
+	if fill.mode == FillPixelate {
+		blocks = fill.averages(original)
+	}
+
+	resp := NewPixels(original.Shape())
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			switch {
+			case !inRegions(regions, i, j):
+				copy(resp.Values[i][j], original.Values[i][j])
+			case fill.mode == FillPixelate:
+				copy(resp.Values[i][j], blocks.Values[i/fill.blockSize][j/fill.blockSize])
+			default:
+				for k := range resp.Values[i][j] {
+					resp.Values[i][j][k] = uint16(fill.color[k])
+				}
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// region is a rectangle of pixels with top-left corner (x, y).
+type region struct {
+	x, y, width, height int
+}
+
+// contains returns true if the pixel at row i and column j is in the region.
+func (r region) contains(i, j int) bool {
+	return r.y <= i && i < r.y+r.height && r.x <= j && j < r.x+r.width
+}
+
+// inRegions returns true if the pixel at row i and column j is in any of the regions.
+func inRegions(regions []region, i, j int) bool {
+	for _, r := range regions {
+		if r.contains(i, j) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// regions returns the regions to redact, checking they lie within the image. Regions may overlap.
+func (redact) regions(shape Shape, params Params) ([]region, error) {
+	if err := shape.validate(); err != nil {
+		return nil, err
+	}
+
+	value := params.String("regions")
+	if value == "" {
+		return nil, errors.New("missing regions to redact")
+	}
+
+	var resp []region
+	for _, s := range strings.Split(value, ",") {
+		var r region
+		_, err := fmt.Sscanf(s, "%dx%d+%d+%d", &r.width, &r.height, &r.x, &r.y)
+		if err != nil || fmt.Sprintf("%dx%d+%d+%d", r.width, r.height, r.x, r.y) != s {
+			return nil, fmt.Errorf("invalid region %q, expected WIDTHxHEIGHT+X+Y", s)
+		}
+
+		if r.width <= 0 || r.height <= 0 || r.x < 0 || r.y < 0 || r.x+r.width > shape.Width || r.y+r.height > shape.Height {
+			return nil, fmt.Errorf("region %s exceeds image %s", s, shape)
+		}
+
+		resp = append(resp, r)
+	}
+
+	return resp, nil
+}
+
+// fillRule is the fill of the redacted regions. Solid fills are opaque, so the color has an alpha channel of the
+// maximum channel value.
+type fillRule struct {
+	mode      string
+	color     [4]int
+	blockSize int
+}
+
+// fill returns the fill of the parameters with channel values of the bit depth, checking the parameters of its mode.
+func (redact) fill(params Params, bitDepth int) (fillRule, error) {
+	resp := fillRule{mode: params.String("fill")}
+
+	switch resp.mode {
+	case FillSolid:
+		color := params.String("color")
+
+		rgb, err := strconv.ParseUint(color, 16, 24)
+		if err != nil || len(color) != 6 {
+			return fillRule{}, fmt.Errorf("invalid color %q, expected hex encoded RGB", color)
+		}
+
+		scale := depthScale(bitDepth)
+		resp.color = [4]int{int(rgb>>16) * scale, int(rgb>>8&0xff) * scale, int(rgb&0xff) * scale, maxValue(bitDepth)}
+	case FillPixelate:
+		blockSize, err := params.Int("block-size")
+		if err != nil {
+			return fillRule{}, err
+		}
+
+		if blockSize <= 0 {
+			return fillRule{}, fmt.Errorf("invalid block size %d", blockSize)
+		}
+
+		resp.blockSize = blockSize
+	default:
+		return fillRule{}, fmt.Errorf("unsupported fill, %s", resp.mode)
+	}
+
+	return resp, nil
+}
+
+// blocks returns the shape of the pixelate blocks of an image. Blocks at the right and bottom edges may be smaller.
+func (f fillRule) blocks(shape Shape) Shape {
+	return Shape{
+		Width:   (shape.Width + f.blockSize - 1) / f.blockSize,
+		Height:  (shape.Height + f.blockSize - 1) / f.blockSize,
+		Alpha:   shape.Alpha,
+		Depth16: shape.Depth16,
+	}
+}
+
+// averages returns the average pixel values of the pixelate blocks of the original image, rounded to the nearest
+// integer with halves rounded up. The alpha channel, if any, is averaged like the color channels.
+func (f fillRule) averages(original Pixels) Pixels {
+	resp := NewPixels(f.blocks(original.Shape()))
+	for bi := range resp.Values {
+		for bj := range resp.Values[bi] {
+			pixels := f.blockPixels(original.Shape(), bi, bj)
+			for k := range resp.Values[bi][bj] {
+				sum := len(pixels) / 2
+				for _, p := range pixels {
+					sum += int(original.Values[p[0]][p[1]][k])
+				}
+
+				resp.Values[bi][bj][k] = uint16(sum / len(pixels))
+			}
+		}
+	}
+
+	return resp
+}
+
+// blockPixels returns the row and column of the pixels of the block at block row bi and block column bj.
+func (f fillRule) blockPixels(shape Shape, bi, bj int) [][2]int {
+	var resp [][2]int
+	for i := bi * f.blockSize; i < min((bi+1)*f.blockSize, shape.Height); i++ {
+		for j := bj * f.blockSize; j < min((bj+1)*f.blockSize, shape.Width); j++ {
+			resp = append(resp, [2]int{i, j})
+		}
+	}
+
+	return resp
+}
+
+// RedactCircuit represents the arithmetic circuit to prove redact transformations.
+// The regions are public inputs as x, y, width and height, see redact.regions.
+type RedactCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Redacted   [][][]frontend.Variable `gnark:",public"`
+	Regions    [][4]frontend.Variable  `gnark:",public"`
+	// Blocks are the average pixel values of the pixelate blocks, nil for solid fills.
+	Blocks    [][][]frontend.Variable `gnark:",secret"`
+	Fill      string
+	Color     [4]int
+	BlockSize int
+	BitDepth  int
+}
+
+func (c *RedactCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	height, width := len(c.Original), len(c.Original[0])
+
+	// The verifier checks the regions lie within the image, so all compared values are in [0, max(width, height)].
+	comparator := cmp.NewBoundedComparator(api, big.NewInt(int64(max(width, height))), false)
+
+	rows := make([][]frontend.Variable, len(c.Regions))
+	cols := make([][]frontend.Variable, len(c.Regions))
+	for r, region := range c.Regions {
+		rows[r] = spans(api, comparator, height, region[1], region[3])
+		cols[r] = spans(api, comparator, width, region[0], region[2])
+	}
+
+	rule := fillRule{mode: c.Fill, color: c.Color, blockSize: c.BlockSize}
+	if c.Fill == FillPixelate {
+		// Every block value must be the rounded average of its pixel values, see fill.averages.
+		for bi := range c.Blocks {
+			for bj := range c.Blocks[bi] {
+				pixels := rule.blockPixels(Shape{Width: width, Height: height}, bi, bj)
+				for k := range c.Blocks[bi][bj] {
+					sum := frontend.Variable(len(pixels) / 2)
+					for _, p := range pixels {
+						sum = api.Add(sum, c.Original[p[0]][p[1]][k])
+					}
+
+					// Range check the block value, as it is not a public pixel value.
+					api.ToBinary(c.Blocks[bi][bj][k], c.BitDepth)
+					assertDiv(api, sum, c.Blocks[bi][bj][k], len(pixels))
+				}
+			}
+		}
+	}
+
+	for i := 0; i < height; i++ {
+		for j := 0; j < width; j++ {
+			// outside is 1 if the pixel is in none of the regions, 0 otherwise.
+			outside := frontend.Variable(1)
+			for r := range c.Regions {
+				outside = api.Mul(outside, api.Sub(1, api.Mul(rows[r][i], cols[r][j])))
+			}
+
+			// The redacted pixel value must be the original value outside the regions and the fill value inside.
+			for k := range c.Redacted[i][j] {
+				value := frontend.Variable(c.Color[k])
+				if c.Fill == FillPixelate {
+					value = c.Blocks[i/c.BlockSize][j/c.BlockSize][k]
+				}
+
+				diff := api.Sub(c.Original[i][j][k], value)
+				api.AssertIsEqual(c.Redacted[i][j][k], api.Add(value, api.Mul(outside, diff)))
+			}
+		}
+	}
+
+	return nil
+}
+
+// spans returns for every index in [0, n) whether it is in [start, start+size), as 1 or 0.
+func spans(api frontend.API, comparator *cmp.BoundedComparator, n int, start, size frontend.Variable) []frontend.Variable {
+	end := api.Add(start, size)
+
+	resp := make([]frontend.Variable, n)
+	for i := range resp {
+		// start <= i < end
+		resp[i] = api.Mul(api.Sub(1, comparator.IsLess(i, start)), comparator.IsLess(i, end))
+	}
+
+	return resp
+}
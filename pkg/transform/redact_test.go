@@ -0,0 +1,130 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tr, err := Get("redact")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{
+		{{10, 20, 30}, {40, 50, 60}},
+		{{70, 80, 90}, {100, 110, 120}},
+	}}
+
+	tests := []struct {
+		name   string
+		params Params
+		final  Pixels
+	}{
+		{
+			name:   "solid",
+			params: Params{"regions": "1x1+1+0", "color": "ff0080"},
+			final: Pixels{Values: [][][]uint16{
+				{{10, 20, 30}, {255, 0, 128}},
+				{{70, 80, 90}, {100, 110, 120}},
+			}},
+		},
+		{
+			name:   "overlapping regions",
+			params: Params{"regions": "2x1+0+0,1x2+1+0"},
+			final: Pixels{Values: [][][]uint16{
+				{{0, 0, 0}, {0, 0, 0}},
+				{{70, 80, 90}, {0, 0, 0}},
+			}},
+		},
+		{
+			// The block covers the whole image, e.g. (10+40+70+100+2)/4 = 55, also outside the region.
+			name:   "pixelate",
+			params: Params{"regions": "1x2+0+0", "fill": "pixelate", "block-size": "2"},
+			final: Pixels{Values: [][][]uint16{
+				{{55, 65, 75}, {40, 50, 60}},
+				{{55, 65, 75}, {100, 110, 120}},
+			}},
+		},
+		{
+			// Blocks at the edges are clipped to the image.
+			name:   "pixelate edge",
+			params: Params{"regions": "2x2+0+0", "fill": "pixelate", "block-size": "3"},
+			final: Pixels{Values: [][][]uint16{
+				{{55, 65, 75}, {55, 65, 75}},
+				{{55, 65, 75}, {55, 65, 75}},
+			}},
+		},
+		{
+			name:   "pixelate single pixel blocks",
+			params: Params{"regions": "2x2+0+0", "fill": "pixelate", "block-size": "1"},
+			final:  original,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := tr.Apply(original, tt.params.WithDefaults(tr))
+			require.NoError(t, err)
+			require.Equal(t, tt.final, final)
+		})
+	}
+
+	for _, tt := range []struct {
+		params Params
+		err    string
+	}{
+		{params: Params{}, err: "missing regions"},
+		{params: Params{"regions": "2x2"}, err: "invalid region"},
+		{params: Params{"regions": "1x1+0+0,"}, err: "invalid region"},
+		{params: Params{"regions": "2x2+1+0"}, err: "exceeds image"},
+		{params: Params{"regions": "0x1+0+0"}, err: "exceeds image"},
+		{params: Params{"regions": "1x1+0+0", "fill": "blur"}, err: "unsupported fill"},
+		{params: Params{"regions": "1x1+0+0", "color": "fff"}, err: "invalid color"},
+		{params: Params{"regions": "1x1+0+0", "fill": "pixelate", "block-size": "0"}, err: "invalid block size"},
+	} {
+		_, err = tr.Apply(original, tt.params.WithDefaults(tr))
+		require.ErrorContains(t, err, tt.err)
+	}
+}
+
+func TestRedactPublicRegions(t *testing.T) {
+	tr, err := Get("redact")
+	require.NoError(t, err)
+
+	original := Pixels{Values: [][][]uint16{
+		{{10, 20, 30}, {40, 50, 60}},
+		{{70, 80, 90}, {100, 110, 120}},
+	}}
+
+	hash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(hash, nil, nil)
+	require.NoError(t, err)
+
+	for _, fill := range []string{FillSolid, FillPixelate} {
+		t.Run(fill, func(t *testing.T) {
+			params := Params{"regions": "1x2+1+0", "fill": fill, "block-size": "2"}.WithDefaults(tr)
+
+			final, err := tr.Apply(original, params)
+			require.NoError(t, err)
+
+			// The circuit only depends on the number of regions, so the keys can be reused for any single region.
+			cs, err := Compile(BackendGroth16, tr, original.Shape(), final.Shape(), Params{"regions": "1x1+0+0", "fill": fill, "block-size": "2"}.WithDefaults(tr), false)
+			require.NoError(t, err)
+
+			pk, vk, err := Setup(BackendGroth16, cs, nil)
+			require.NoError(t, err)
+
+			proof, err := Prove(BackendGroth16, cs, pk, tr, original, final, params, provenance)
+			require.NoError(t, err)
+			require.NoError(t, Verify(BackendGroth16, tr, proof, vk, final, params, provenance))
+
+			// The verifier provides the regions claimed by the prover.
+			for _, regions := range []string{"1x2+0+0", "1x1+1+1", "2x2+0+0"} {
+				params["regions"] = regions
+				err = Verify(BackendGroth16, tr, proof, vk, final, params, provenance)
+				require.Error(t, err)
+			}
+		})
+	}
+}
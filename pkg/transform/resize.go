@@ -0,0 +1,235 @@
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+)
+
+func init() {
+	Register(resize{})
+}
+
+const (
+	FilterNearest  = "nearest"
+	FilterBox      = "box"
+	FilterBilinear = "bilinear"
+)
+
+// bilinearShift is the number of fractional bits of the bilinear filter weights.
+const bilinearShift = 8
+
+// resize resizes the original image to the final image dimensions with a resampling filter.
+type resize struct{}
+
+func (resize) Name() string {
+	return "resize"
+}
+
+func (resize) Description() string {
+	return "Resizes the original image."
+}
+
+func (resize) Params() []Param {
+	return []Param{
+		{
+			Name:    "width-new",
+			Usage:   "The width of the resized image, zero to keep the aspect ratio of the original image.",
+			Kind:    ParamInt,
+			Default: "0",
+		},
+		{
+			Name:    "height-new",
+			Usage:   "The height of the resized image, zero to keep the aspect ratio of the original image.",
+			Kind:    ParamInt,
+			Default: "0",
+		},
+		{
+			Name:    "filter",
+			Usage:   "The resampling filter. Supported: nearest, box and bilinear.",
+			Kind:    ParamString,
+			Default: FilterBilinear,
+		},
+	}
+}
+
+func (r resize) Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error) {
+	size, err := r.size(original, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expectShape(final, size); err != nil {
+		return nil, err
+	}
+
+	return &ResizeCircuit{
+		Provenance: provenanceCircuit(signed),
+		Original:   original.variables(),
+		Resized:    final.variables(),
+		Filter:     params.String("filter"),
+		BitDepth:   original.BitDepth(),
+	}, nil
+}
+
+func (resize) Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error) {
+	return &ResizeCircuit{
+		Provenance: provenance,
+		Original:   original.variables(),
+		Resized:    final.variables(),
+		Filter:     params.String("filter"),
+		BitDepth:   final.Shape().BitDepth(),
+	}, nil
+}
+
+func (r resize) Apply(original Pixels, params Params) (Pixels, error) {
+	size, err := r.size(original.Shape(), params)
+	if err != nil {
+		return Pixels{}, err
+	}
+
+	filter := params.String("filter")
+	rows := resampling(filter, original.Shape().Height, size.Height)
+	cols := resampling(filter, original.Shape().Width, size.Width)
+
+	resp := NewPixels(size)
+	for i := range resp.Values {
+		for j := range resp.Values[i] {
+			divisor := rows[i].scale * cols[j].scale
+			for k := range resp.Values[i][j] {
+				sum := divisor / 2
+				for _, row := range rows[i].taps {
+					for _, col := range cols[j].taps {
+						sum += row.weight * col.weight * int(original.Values[row.index][col.index][k])
+					}
+				}
+
+				resp.Values[i][j][k] = uint16(sum / divisor)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// size returns the shape of the resized image, checking the parameters.
+func (resize) size(original Shape, params Params) (Shape, error) {
+	if err := original.validate(); err != nil {
+		return Shape{}, err
+	}
+
+	switch filter := params.String("filter"); filter {
+	case FilterNearest, FilterBox, FilterBilinear:
+	default:
+		return Shape{}, fmt.Errorf("unsupported filter, %s", filter)
+	}
+
+	width, err := params.Int("width-new")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	height, err := params.Int("height-new")
+	if err != nil {
+		return Shape{}, err
+	}
+
+	// Derive the missing dimension from the aspect ratio, rounded to the nearest integer.
+	switch {
+	case width < 0 || height < 0 || (width == 0 && height == 0):
+		return Shape{}, fmt.Errorf("invalid resized image size %dx%d", width, height)
+	case width == 0:
+		width = max((2*original.Width*height+original.Height)/(2*original.Height), 1)
+	case height == 0:
+		height = max((2*original.Height*width+original.Width)/(2*original.Width), 1)
+	}
+
+	return Shape{Width: width, Height: height, Alpha: original.Alpha, Depth16: original.Depth16}, nil
+}
+
+// sampling is the weighted sum of original pixels along one axis that is resampled to a final pixel.
+type sampling struct {
+	taps []tap
+	// scale is the sum of the tap weights.
+	scale int
+}
+
+// tap is the weight of an original pixel index in a sampling.
+type tap struct {
+	index  int
+	weight int
+}
+
+// resampling returns the sampling of every final pixel along an axis of n original pixels resized to size pixels.
+// Pixel i covers the interval [i, i+1) and its center is i+1/2, all arithmetic is on integers:
+//   - nearest: the original pixel containing the final pixel center, i.e. floor((2i+1)*n / 2size).
+//   - box: the average of the original pixels overlapping the final pixel, i.e. from floor(i*n/size) to ceil((i+1)*n/size).
+//   - bilinear: the two original pixels around the final pixel center, weighted by their distance to it in 8-bit
+//     fixed point, rounded to the nearest integer with halves rounded up. Centers beyond the edges are clamped.
+func resampling(filter string, n, size int) []sampling {
+	resp := make([]sampling, size)
+	for i := range resp {
+		switch filter {
+		case FilterNearest:
+			resp[i] = sampling{taps: []tap{{index: (2*i + 1) * n / (2 * size), weight: 1}}, scale: 1}
+		case FilterBox:
+			start, end := i*n/size, ((i+1)*n+size-1)/size
+			for index := start; index < end; index++ {
+				resp[i].taps = append(resp[i].taps, tap{index: index, weight: 1})
+			}
+			resp[i].scale = end - start
+		case FilterBilinear:
+			// The original coordinate of the center is num/den - 1/2 in original pixel centers.
+			num, den := max((2*i+1)*n-size, 0), 2*size
+			index := min(num/den, n-1)
+			weight := ((num-index*den)<<bilinearShift + den/2) / den
+			if index == n-1 {
+				weight = 0
+			}
+
+			resp[i].taps = []tap{{index: index, weight: 1<<bilinearShift - weight}}
+			if weight > 0 {
+				resp[i].taps = append(resp[i].taps, tap{index: index + 1, weight: weight})
+			}
+			resp[i].scale = 1 << bilinearShift
+		}
+	}
+
+	return resp
+}
+
+// ResizeCircuit represents the arithmetic circuit to prove resize transformations.
+type ResizeCircuit struct {
+	Provenance Provenance
+	Original   [][][]frontend.Variable `gnark:",secret"`
+	Resized    [][][]frontend.Variable `gnark:",public"`
+	Filter     string
+	BitDepth   int
+}
+
+func (c *ResizeCircuit) Define(api frontend.API) error {
+	if err := c.Provenance.Assert(api, c.Original, c.BitDepth); err != nil {
+		return err
+	}
+
+	rows := resampling(c.Filter, len(c.Original), len(c.Resized))
+	cols := resampling(c.Filter, len(c.Original[0]), len(c.Resized[0]))
+
+	// Every final pixel value must be the rounded weighted sum of the original pixel values, see resize.Apply.
+	for i := 0; i < len(c.Resized); i++ {
+		for j := 0; j < len(c.Resized[i]); j++ {
+			divisor := rows[i].scale * cols[j].scale
+			for k := range c.Resized[i][j] {
+				sum := frontend.Variable(divisor / 2)
+				for _, row := range rows[i].taps {
+					for _, col := range cols[j].taps {
+						sum = api.Add(sum, api.Mul(c.Original[row.index][col.index][k], row.weight*col.weight))
+					}
+				}
+
+				assertDiv(api, sum, c.Resized[i][j][k], divisor)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package transform
+
+import (
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	tr, err := Get("resize")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		original []uint16
+		filter   string
+		final    []uint16
+	}{
+		{
+			// The original pixels containing the final pixel centers 1 and 3.
+			name:     "nearest downscale",
+			original: []uint16{10, 20, 30, 40},
+			filter:   FilterNearest,
+			final:    []uint16{20, 40},
+		},
+		{
+			name:     "nearest upscale",
+			original: []uint16{10, 20},
+			filter:   FilterNearest,
+			final:    []uint16{10, 10, 20, 20},
+		},
+		{
+			// (10+20+1)/2 and (30+40+1)/2.
+			name:     "box downscale",
+			original: []uint16{10, 20, 30, 40},
+			filter:   FilterBox,
+			final:    []uint16{15, 35},
+		},
+		{
+			// Final pixel 0 overlaps original pixels 0 and 1, and final pixel 1 overlaps original pixels 1 and 2.
+			name:     "box fractional",
+			original: []uint16{10, 20, 30},
+			filter:   FilterBox,
+			final:    []uint16{15, 25},
+		},
+		{
+			// The final pixel centers are at -1/4, 1/4, 3/4 and 5/4 original pixels, clamped to [0, 1],
+			// so the weights of the second original pixel are 0, 64/256, 192/256 and 1.
+			name:     "bilinear upscale",
+			original: []uint16{0, 100},
+			filter:   FilterBilinear,
+			final:    []uint16{0, 25, 75, 100},
+		},
+		{
+			// The final pixel centers are at 1/2 and 5/2 original pixels, (50+0.5) and (227.5+0.5) are rounded down.
+			name:     "bilinear downscale",
+			original: []uint16{0, 100, 200, 255},
+			filter:   FilterBilinear,
+			final:    []uint16{50, 228},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := NewPixels(Shape{Width: len(tt.original), Height: 1})
+			for j, v := range tt.original {
+				original.Values[0][j] = []uint16{v, v, v}
+			}
+
+			params := Params{"width-new": strconv.Itoa(len(tt.final)), "height-new": "1", "filter": tt.filter}
+
+			final, err := tr.Apply(original, params)
+			require.NoError(t, err)
+			require.Equal(t, Shape{Width: len(tt.final), Height: 1}, final.Shape())
+
+			for j, v := range tt.final {
+				require.Equal(t, []uint16{v, v, v}, final.Values[0][j], "pixel %d", j)
+			}
+		})
+	}
+
+	t.Run("aspect ratio", func(t *testing.T) {
+		shape, err := FinalShape(tr, Shape{Width: 10, Height: 5}, Params{"width-new": "3"}.WithDefaults(tr))
+		require.NoError(t, err)
+		require.Equal(t, Shape{Width: 3, Height: 2}, shape)
+
+		_, err = FinalShape(tr, Shape{Width: 10, Height: 5}, Params{}.WithDefaults(tr))
+		require.ErrorContains(t, err, "invalid resized image size")
+
+		_, err = FinalShape(tr, Shape{Width: 10, Height: 5}, Params{"width-new": "3", "filter": "lanczos"}.WithDefaults(tr))
+		require.ErrorContains(t, err, "unsupported filter")
+	})
+}
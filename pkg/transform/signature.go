@@ -0,0 +1,120 @@
+package transform
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	stdmimc "github.com/consensys/gnark/std/hash/mimc"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+	"math/big"
+)
+
+// Provenance binds the secret original image of a circuit to public inputs: the original image hash
+// and, optionally, the public key of the signer of that hash, e.g. a capture device.
+type Provenance struct {
+	OriginalHash frontend.Variable `gnark:",public"`
+	// Signer holds zero or one signature, so unsigned proofs carry no signer public inputs.
+	Signer []Signature
+}
+
+// Signature represents an EdDSA (BabyJubJub over BN254) signature over the original image hash.
+type Signature struct {
+	PublicKey stdeddsa.PublicKey `gnark:",public"`
+	Signature stdeddsa.Signature `gnark:",secret"`
+}
+
+// NewProvenance returns the provenance assignment for the original image hash. The public key may be nil
+// if the original image is not signed. The signature may be nil if only the public witness is required.
+func NewProvenance(originalHash *big.Int, publicKey *eddsa.PublicKey, signature []byte) (Provenance, error) {
+	resp := Provenance{OriginalHash: originalHash}
+	if publicKey == nil {
+		if signature != nil {
+			return Provenance{}, errors.New("signature provided without signer public key")
+		}
+
+		return resp, nil
+	}
+
+	var sig Signature
+	sig.PublicKey.Assign(tedwards.BN254, publicKey.Bytes())
+
+	if signature != nil {
+		// Check the signature natively to fail early with a meaningful error.
+		ok, err := publicKey.Verify(signature, hashToBytes(originalHash), mimc.NewMiMC())
+		if err != nil {
+			return Provenance{}, err
+		} else if !ok {
+			return Provenance{}, errors.New("signature does not match the original image and signer public key")
+		}
+
+		sig.Signature.Assign(tedwards.BN254, signature)
+	}
+
+	resp.Signer = []Signature{sig}
+
+	return resp, nil
+}
+
+// provenanceCircuit returns the provenance circuit definition.
+func provenanceCircuit(signed bool) Provenance {
+	if !signed {
+		return Provenance{}
+	}
+
+	return Provenance{Signer: make([]Signature, 1)}
+}
+
+// Signed returns true if the provenance includes a signer.
+func (p Provenance) Signed() bool {
+	return len(p.Signer) > 0
+}
+
+// Assert constrains the original image with channel values of the bit depth to match the original image hash, and the
+// hash to be signed by the signer, if any.
+func (p Provenance) Assert(api frontend.API, original [][][]frontend.Variable, bitDepth int) error {
+	// The original image must be the one committed to by the public hash.
+	if err := assertPixelsHash(api, original, bitDepth, p.OriginalHash); err != nil {
+		return err
+	}
+
+	if len(p.Signer) == 0 {
+		return nil
+	}
+
+	// The original image hash must be signed by the signer.
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+
+	for _, sig := range p.Signer {
+		h, err := stdmimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+
+		if err := stdeddsa.Verify(curve, sig.Signature, p.OriginalHash, sig.PublicKey, &h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SignHash returns the EdDSA signature of the image hash by the private key.
+func SignHash(privKey *eddsa.PrivateKey, hash *big.Int) ([]byte, error) {
+	return privKey.Sign(hashToBytes(hash), mimc.NewMiMC())
+}
+
+// hashToBytes returns the big-endian field element encoding of the hash, i.e. the signed message.
+func hashToBytes(hash *big.Int) []byte {
+	var elem fr.Element
+	elem.SetBigInt(hash)
+	b := elem.Bytes()
+
+	return b[:]
+}
@@ -0,0 +1,315 @@
+package transform
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/constraint"
+	"io"
+	"math/big"
+)
+
+// ptauMagic prefixes powers of tau files in the snarkjs format, used by the perpetual powers of tau ceremony.
+var ptauMagic = []byte("ptau")
+
+// ptau section ids, see https://github.com/iden3/snarkjs/blob/master/src/powersoftau_new.js.
+const (
+	ptauSectionHeader  = 1
+	ptauSectionTauG1   = 2
+	ptauSectionTauG2   = 3
+	ptauSectionAlphaG1 = 4
+	ptauSectionBetaG1  = 5
+	ptauSectionBetaG2  = 6
+)
+
+// ReadSRS reads the first size G1 powers of a KZG SRS for the plonk backend over BN254, see SRSSize, in either the
+// snarkjs powers of tau format (.ptau), e.g. from the perpetual powers of tau ceremony, or the gnark native format
+// written by kzg.SRS.WriteTo. Only the first size powers of a ptau file are read.
+// It returns an error if the SRS has fewer powers or the points are not valid powers of the same secret.
+func ReadSRS(r io.Reader, size int) (*kzg.SRS, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(len(ptauMagic))
+	if err != nil {
+		return nil, fmt.Errorf("read srs, %w", err)
+	}
+
+	srs := new(kzg.SRS)
+	if bytes.Equal(magic, ptauMagic) {
+		srs, err = readPtau(br, size)
+	} else {
+		_, err = srs.ReadFrom(br)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read srs, %w", err)
+	}
+
+	if len(srs.Pk.G1) < size {
+		return nil, fmt.Errorf("kzg srs has %d powers, the circuit requires %d", len(srs.Pk.G1), size)
+	}
+	srs.Pk.G1 = srs.Pk.G1[:size]
+
+	if err := checkSRS(srs); err != nil {
+		return nil, err
+	}
+
+	return srs, nil
+}
+
+// SRSSize returns the minimum number of G1 powers of a KZG SRS for the plonk setup of the constraint system.
+func SRSSize(cs constraint.ConstraintSystem) int {
+	// plonk commits to polynomials over the smallest power of two domain, plus 3 for blinding.
+	return int(ecc.NextPowerOfTwo(uint64(cs.GetNbConstraints()+cs.GetNbPublicVariables()))) + 3
+}
+
+// NewInsecureSRS returns a KZG SRS of the provided size from a random secret that is discarded but never
+// attested by anyone else. It is only suitable for testing, use ReadSRS with the output of a ceremony instead.
+func NewInsecureSRS(size int) (*kzg.SRS, error) {
+	alpha, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+
+	return kzg.NewSRS(uint64(size), alpha)
+}
+
+// checkSRS returns an error if the SRS is not of the form [G₁, [τ]G₁, [τ²]G₁, ...], [G₂, [τ]G₂].
+func checkSRS(srs *kzg.SRS) error {
+	_, _, g1, g2 := bn254.Generators()
+
+	powers := srs.Pk.G1
+	switch {
+	case len(powers) < 2:
+		return fmt.Errorf("srs has %d powers, at least 2 are required", len(powers))
+	case !powers[0].Equal(&g1) || !srs.Vk.G1.Equal(&g1) || !srs.Vk.G2[0].Equal(&g2):
+		return errors.New("srs does not start with the generators")
+	case powers[1].IsInfinity() || srs.Vk.G2[1].IsInfinity() || !srs.Vk.G2[1].IsInSubGroup():
+		return errors.New("srs has an invalid secret")
+	}
+
+	for i := range powers {
+		if !powers[i].IsInSubGroup() {
+			return fmt.Errorf("srs power %d is not a valid point", i)
+		}
+	}
+
+	if err := checkPowers(powers, srs.Vk.G2[1]); err != nil {
+		return fmt.Errorf("srs points are not consecutive powers of the same secret, %w", err)
+	}
+
+	return nil
+}
+
+// checkPowers returns an error if the G1 points are not consecutive powers of the secret τ of [τ]G₂, i.e. Pᵢ₊₁ = [τ]Pᵢ.
+// The powers are checked at once with a random linear combination: e(∑rᵢPᵢ₊₁, G₂) = e(∑rᵢPᵢ, [τ]G₂).
+func checkPowers(powers []bn254.G1Affine, tauG2 bn254.G2Affine) error {
+	_, _, _, g2 := bn254.Generators()
+
+	scalars := make([]fr.Element, len(powers)-1)
+	for i := range scalars {
+		if _, err := scalars[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	var next, prev bn254.G1Affine
+	if _, err := next.MultiExp(powers[1:], scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if _, err := prev.MultiExp(powers[:len(powers)-1], scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	prev.Neg(&prev)
+
+	ok, err := bn254.PairingCheck([]bn254.G1Affine{next, prev}, []bn254.G2Affine{g2, tauG2})
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.New("pairing check failed")
+	}
+
+	return nil
+}
+
+// readPtau reads the first size tau powers of a snarkjs powers of tau file for the KZG SRS.
+func readPtau(r io.Reader, size int) (*kzg.SRS, error) {
+	var (
+		srs = new(kzg.SRS)
+		g2  []bn254.G2Affine
+	)
+	err := readPtauSections(r, func(power int, section uint32, data io.Reader) error {
+		var err error
+		switch section {
+		case ptauSectionTauG1:
+			// The ceremony computes twice as many G1 powers as G2 powers.
+			if n := 2<<power - 1; n < size {
+				return fmt.Errorf("kzg srs has %d powers, the circuit requires %d", n, size)
+			}
+			srs.Pk.G1, err = readPtauG1s(data, size)
+		case ptauSectionTauG2:
+			g2, err = readPtauG2s(data, 2)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(srs.Pk.G1) == 0 || len(g2) == 0 {
+		return nil, errors.New("ptau file is missing the tau powers")
+	}
+
+	srs.Vk.G1 = srs.Pk.G1[0]
+	srs.Vk.G2 = [2]bn254.G2Affine{g2[0], g2[1]}
+
+	return srs, nil
+}
+
+// readPtauSections calls read with the power of the snarkjs powers of tau file and the data of each section
+// following the header. Points are stored in affine coordinates, with each base field element in little-endian
+// Montgomery form. The unread data of each section is skipped.
+func readPtauSections(r io.Reader, read func(power int, section uint32, data io.Reader) error) error {
+	var header struct {
+		Magic    [4]byte
+		Version  uint32
+		Sections uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+
+	power := -1
+	for i := 0; i < int(header.Sections); i++ {
+		var section struct {
+			ID   uint32
+			Size uint64
+		}
+		if err := binary.Read(r, binary.LittleEndian, &section); err != nil {
+			return err
+		}
+
+		data := io.LimitReader(r, int64(section.Size))
+
+		if section.ID == ptauSectionHeader {
+			var err error
+			if power, err = readPtauHeader(data); err != nil {
+				return err
+			}
+		} else if power < 0 {
+			return errors.New("ptau sections precede the header")
+		} else if err := read(power, section.ID, data); err != nil {
+			return fmt.Errorf("ptau section %d, %w", section.ID, err)
+		}
+
+		if _, err := io.Copy(io.Discard, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readPtauHeader returns the power of the ptau file, the log2 of the number of G2 powers.
+func readPtauHeader(r io.Reader) (int, error) {
+	var n8 uint32
+	if err := binary.Read(r, binary.LittleEndian, &n8); err != nil {
+		return 0, err
+	} else if n8 != fp.Bytes {
+		return 0, fmt.Errorf("ptau has %d byte field elements, only bn254 is supported", n8)
+	}
+
+	q := make([]byte, n8)
+	if _, err := io.ReadFull(r, q); err != nil {
+		return 0, err
+	} else if new(big.Int).SetBytes(reverse(q)).Cmp(fp.Modulus()) != 0 {
+		return 0, errors.New("ptau field is not bn254")
+	}
+
+	var power uint32
+	if err := binary.Read(r, binary.LittleEndian, &power); err != nil {
+		return 0, err
+	} else if power > 28 {
+		return 0, fmt.Errorf("ptau power %d is too large", power)
+	}
+
+	return int(power), nil
+}
+
+// readPtauG1s reads n G1 points of a ptau section.
+func readPtauG1s(r io.Reader, n int) ([]bn254.G1Affine, error) {
+	resp := make([]bn254.G1Affine, n)
+	for i := range resp {
+		if err := readPtauG1(r, &resp[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// readPtauG2s reads n G2 points of a ptau section.
+func readPtauG2s(r io.Reader, n int) ([]bn254.G2Affine, error) {
+	resp := make([]bn254.G2Affine, n)
+	for i := range resp {
+		if err := readPtauG2(r, &resp[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func readPtauG1(r io.Reader, p *bn254.G1Affine) error {
+	if err := readPtauFp(r, &p.X); err != nil {
+		return err
+	}
+
+	return readPtauFp(r, &p.Y)
+}
+
+func readPtauG2(r io.Reader, p *bn254.G2Affine) error {
+	for _, e := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+		if err := readPtauFp(r, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readPtauFp reads a base field element in little-endian Montgomery form, which is the in-memory form of fp.Element.
+func readPtauFp(r io.Reader, e *fp.Element) error {
+	var buf [fp.Bytes]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return err
+	}
+
+	for i := range e {
+		e[i] = binary.LittleEndian.Uint64(buf[8*i:])
+	}
+
+	if new(big.Int).SetBytes(reverse(buf[:])).Cmp(fp.Modulus()) >= 0 {
+		return errors.New("ptau field element is not reduced")
+	}
+
+	return nil
+}
+
+// reverse returns the bytes in reverse order, converting between little and big-endian.
+func reverse(b []byte) []byte {
+	resp := make([]byte, len(b))
+	for i := range b {
+		resp[len(b)-1-i] = b[i]
+	}
+
+	return resp
+}
@@ -0,0 +1,174 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func TestReadSRS(t *testing.T) {
+	const power = 4
+	srs, ptau := newPtau(t, power)
+
+	size := len(srs.Pk.G1)
+
+	t.Run("ptau", func(t *testing.T) {
+		got, err := ReadSRS(bytes.NewReader(ptau), size)
+		require.NoError(t, err)
+		require.Equal(t, srs, got)
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		got, err := ReadSRS(bytes.NewReader(ptau), 5)
+		require.NoError(t, err)
+		require.Equal(t, srs.Pk.G1[:5], got.Pk.G1)
+		require.Equal(t, srs.Vk, got.Vk)
+
+		_, err = ReadSRS(bytes.NewReader(ptau), size+1)
+		require.ErrorContains(t, err, "the circuit requires")
+	})
+
+	t.Run("gnark", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		_, err := srs.WriteTo(buf)
+		require.NoError(t, err)
+
+		got, err := ReadSRS(buf, size)
+		require.NoError(t, err)
+		require.Equal(t, srs, got)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := *srs
+		tampered.Pk.G1 = append([]bn254.G1Affine(nil), srs.Pk.G1...)
+		tampered.Pk.G1[3], tampered.Pk.G1[4] = tampered.Pk.G1[4], tampered.Pk.G1[3]
+
+		buf := new(bytes.Buffer)
+		_, err := tampered.WriteTo(buf)
+		require.NoError(t, err)
+
+		_, err = ReadSRS(buf, size)
+		require.ErrorContains(t, err, "not consecutive powers")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := ReadSRS(bytes.NewReader(ptau[:len(ptau)/2]), size)
+		require.Error(t, err)
+
+		_, err = ReadSRS(bytes.NewReader(nil), size)
+		require.Error(t, err)
+	})
+}
+
+func TestSetupSRS(t *testing.T) {
+	crop, err := Get("crop")
+	require.NoError(t, err)
+
+	shape := Shape{Width: 2, Height: 2}
+	cs, err := Compile(BackendPlonk, crop, shape, shape, Params{}.WithDefaults(crop), false)
+	require.NoError(t, err)
+
+	_, _, err = Setup(BackendPlonk, cs, nil)
+	require.ErrorContains(t, err, "missing kzg srs")
+
+	small, err := NewInsecureSRS(SRSSize(cs) - 1)
+	require.NoError(t, err)
+
+	_, _, err = Setup(BackendPlonk, cs, small)
+	require.ErrorContains(t, err, "the circuit requires")
+
+	srs, err := NewInsecureSRS(SRSSize(cs))
+	require.NoError(t, err)
+
+	pk, vk, err := Setup(BackendPlonk, cs, srs)
+	require.NoError(t, err)
+	require.NotEmpty(t, pk)
+	require.NotEmpty(t, vk)
+}
+
+// newPtau returns a KZG SRS and its encoding as a snarkjs powers of tau file of the provided power.
+func newPtau(t *testing.T, power int) (*kzg.SRS, []byte) {
+	t.Helper()
+
+	tau, alpha, beta := big.NewInt(1234567), big.NewInt(89), big.NewInt(1011)
+	srs, err := kzg.NewSRS(2<<power-1, tau)
+	require.NoError(t, err)
+
+	_, _, _, g2 := bn254.Generators()
+	tauG2 := make([]bn254.G2Affine, 1<<power)
+	alphaG1 := make([]bn254.G1Affine, 1<<power)
+	betaG1 := make([]bn254.G1Affine, 1<<power)
+	for i := range tauG2 {
+		tauG2[i].ScalarMultiplication(&g2, new(big.Int).Exp(tau, big.NewInt(int64(i)), ecc.BN254.ScalarField()))
+		alphaG1[i].ScalarMultiplication(&srs.Pk.G1[i], alpha)
+		betaG1[i].ScalarMultiplication(&srs.Pk.G1[i], beta)
+	}
+
+	var betaG2 bn254.G2Affine
+	betaG2.ScalarMultiplication(&g2, beta)
+
+	header := new(bytes.Buffer)
+	writeLE(t, header, uint32(fp.Bytes))
+	q := fp.Modulus().Bytes()
+	header.Write(reverse(append(make([]byte, fp.Bytes-len(q)), q...)))
+	writeLE(t, header, uint32(power))
+	writeLE(t, header, uint32(28))
+
+	g1s := new(bytes.Buffer)
+	for _, p := range srs.Pk.G1 {
+		writeLE(t, g1s, p.X, p.Y)
+	}
+
+	g2s := new(bytes.Buffer)
+	for _, p := range tauG2 {
+		writeLE(t, g2s, p.X.A0, p.X.A1, p.Y.A0, p.Y.A1)
+	}
+
+	alphas := new(bytes.Buffer)
+	betas := new(bytes.Buffer)
+	for i := range alphaG1 {
+		writeLE(t, alphas, alphaG1[i].X, alphaG1[i].Y)
+		writeLE(t, betas, betaG1[i].X, betaG1[i].Y)
+	}
+
+	betaG2s := new(bytes.Buffer)
+	writeLE(t, betaG2s, betaG2.X.A0, betaG2.X.A1, betaG2.Y.A0, betaG2.Y.A1)
+
+	sections := []struct {
+		id   uint32
+		data []byte
+	}{
+		{ptauSectionHeader, header.Bytes()},
+		{ptauSectionTauG1, g1s.Bytes()},
+		{ptauSectionTauG2, g2s.Bytes()},
+		{ptauSectionAlphaG1, alphas.Bytes()},
+		{ptauSectionBetaG1, betas.Bytes()},
+		{ptauSectionBetaG2, betaG2s.Bytes()},
+		{7, []byte("contributions are skipped")},
+	}
+
+	resp := new(bytes.Buffer)
+	resp.Write(ptauMagic)
+	writeLE(t, resp, uint32(1), uint32(len(sections)))
+	for _, s := range sections {
+		writeLE(t, resp, s.id, uint64(len(s.data)))
+		resp.Write(s.data)
+	}
+
+	return srs, resp.Bytes()
+}
+
+// writeLE writes the values in little-endian, fp.Element limbs are in Montgomery form as in ptau files.
+func writeLE(t *testing.T, buf *bytes.Buffer, values ...any) {
+	t.Helper()
+
+	for _, v := range values {
+		require.NoError(t, binary.Write(buf, binary.LittleEndian, v))
+	}
+}
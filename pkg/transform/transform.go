@@ -0,0 +1,159 @@
+// Package transform defines the image transformations whose correctness can be proven in zero knowledge.
+//
+// Every Transformation provides its circuit, its witness assignment and a reference implementation on pixels.
+// Transformations register themselves in a registry, from which the maya CLI generates its commands.
+package transform
+
+import (
+	"fmt"
+	"github.com/consensys/gnark/frontend"
+	"sort"
+	"strconv"
+)
+
+const (
+	MinPixelValue = 0
+	MaxPixelValue = 255
+)
+
+// Transformation is an image transformation that can be proven with a zk circuit.
+type Transformation interface {
+	// Name returns the unique name of the transformation, e.g. "crop".
+	Name() string
+	// Description returns a short human-readable description of the transformation.
+	Description() string
+	// Params returns the schema of the transformation parameters.
+	Params() []Param
+	// Circuit returns the circuit definition to compile for the provided image shapes and parameters.
+	Circuit(original, final Shape, params Params, signed bool) (frontend.Circuit, error)
+	// Assignment returns the witness assignment of the circuit. The original pixels are empty
+	// when only the public witness is required, e.g. for verification.
+	Assignment(original, final Pixels, params Params, provenance Provenance) (frontend.Circuit, error)
+	// Apply returns the final pixels by applying the transformation to the original pixels.
+	// It is the reference implementation the circuit is checked against.
+	Apply(original Pixels, params Params) (Pixels, error)
+}
+
+// Resolver is implemented by transformations with public parameters that default to properties of the images,
+// e.g. their dimensions, so provers may omit them while verifiers must provide the resolved values.
+type Resolver interface {
+	// Resolve returns a copy of the params with the defaults resolved for the provided image shapes.
+	Resolve(original, final Shape, params Params) Params
+}
+
+// ParamKind is the type of parameter value.
+type ParamKind int
+
+const (
+	ParamInt ParamKind = iota
+	ParamFloat
+	ParamString
+)
+
+// Param describes a transformation parameter.
+type Param struct {
+	// Name of the parameter, also used as CLI flag name.
+	Name string
+	// Usage is the human-readable description of the parameter.
+	Usage string
+	// Kind is the type of the parameter value.
+	Kind ParamKind
+	// Default is the string encoded default value of the parameter.
+	Default string
+	// Public is true if the parameter is a public input of the circuit, so verifiers must provide it.
+	// Other parameters are compiled into the circuit, so they are defined by the verifying key.
+	Public bool
+	// Optional is true if verifiers may omit the public parameter, e.g. as it is an alternative to another one.
+	Optional bool
+}
+
+// Params holds the string encoded values of transformation parameters by name.
+type Params map[string]string
+
+// Int returns the integer value of the named parameter.
+func (p Params) Int(name string) (int, error) {
+	v, err := strconv.Atoi(p[name])
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s parameter, %w", name, err)
+	}
+
+	return v, nil
+}
+
+// Float returns the floating-point value of the named parameter.
+func (p Params) Float(name string) (float64, error) {
+	v, err := strconv.ParseFloat(p[name], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s parameter, %w", name, err)
+	}
+
+	return v, nil
+}
+
+// String returns the value of the named parameter.
+func (p Params) String(name string) string {
+	return p[name]
+}
+
+// WithDefaults returns a copy of the params with defaults set for any parameter of t that is missing.
+func (p Params) WithDefaults(t Transformation) Params {
+	resp := make(Params)
+	for _, param := range t.Params() {
+		resp[param.Name] = param.Default
+	}
+
+	for k, v := range p {
+		resp[k] = v
+	}
+
+	return resp
+}
+
+var registry = make(map[string]Transformation)
+
+// Register adds the transformation to the registry. It panics if the name is already registered.
+func Register(t Transformation) {
+	if _, ok := registry[t.Name()]; ok {
+		panic(fmt.Sprintf("transformation already registered, %s", t.Name()))
+	}
+
+	registry[t.Name()] = t
+}
+
+// Get returns the registered transformation by name.
+func Get(name string) (Transformation, error) {
+	t, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transformation, %s", name)
+	}
+
+	return t, nil
+}
+
+// All returns all registered transformations sorted by name.
+func All() []Transformation {
+	var resp []Transformation
+	for _, t := range registry {
+		resp = append(resp, t)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Name() < resp[j].Name()
+	})
+
+	return resp
+}
+
+// FinalShape returns the shape of the final image of the transformation of an original image of the provided shape.
+func FinalShape(t Transformation, original Shape, params Params) (Shape, error) {
+	if err := original.validate(); err != nil {
+		return Shape{}, err
+	}
+
+	final, err := t.Apply(NewPixels(original), params)
+	if err != nil {
+		return Shape{}, err
+	}
+
+	return final.Shape(), nil
+}
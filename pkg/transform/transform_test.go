@@ -0,0 +1,497 @@
+package transform
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	_ "image/png"
+	"os"
+	"testing"
+)
+
+func TestTransformations(t *testing.T) {
+	tests := []struct {
+		name string
+		// final is the path of the sample final image, if any.
+		final  string
+		params Params
+	}{
+		{
+			name:   "crop",
+			final:  "../../sample/cropped.png",
+			params: Params{"width-new": "5", "height-new": "5"},
+		},
+		{
+			name:   "crop",
+			final:  "../../sample/cropped2.png",
+			params: Params{"width-start-new": "2", "height-start-new": "2", "width-new": "7", "height-new": "7"},
+		},
+		{
+			name:  "rotate90",
+			final: "../../sample/rotated90.png",
+		},
+		{
+			name:  "rotate180",
+			final: "../../sample/rotated180.png",
+		},
+		{
+			name:  "rotate270",
+			final: "../../sample/rotated270.png",
+		},
+		{
+			name:  "flip-vertical",
+			final: "../../sample/flipped_vertical.png",
+		},
+		{
+			name:  "flip-horizontal",
+			final: "../../sample/flipped_horizontal.png",
+		},
+		{
+			name:  "identity",
+			final: "../../sample/original.png",
+		},
+		{
+			name:  "reencode",
+			final: "../../sample/original.png",
+		},
+		{
+			name:   "dihedral",
+			final:  "../../sample/transposed.png",
+			params: Params{"symmetry": "transpose"},
+		},
+		{
+			name:   "dihedral",
+			final:  "../../sample/rotated270.png",
+			params: Params{"symmetry": "rotate270"},
+		},
+		{
+			name:   "dihedral",
+			params: Params{"symmetry": "anti-transpose"},
+		},
+		{
+			name:   "brighten",
+			final:  "../../sample/brightened.png",
+			params: Params{"brightening-factor": "2"},
+		},
+		{
+			name:   "brighten",
+			params: Params{"brightening-factor": "-40.5"},
+		},
+		{
+			name:   "brighten",
+			params: Params{"brightening-factor": "-3", "brightening-multiplier": "1.1"},
+		},
+		{
+			name:  "grayscale",
+			final: "../../sample/grayscale.png",
+		},
+		{
+			name:   "grayscale",
+			params: Params{"standard": "bt709"},
+		},
+		{
+			name:   "contrast",
+			params: Params{"factor": "1.25"},
+		},
+		{
+			name:   "contrast",
+			params: Params{"factor": "0.5"},
+		},
+		{
+			name:   "resize",
+			params: Params{"width-new": "5", "filter": "nearest"},
+		},
+		{
+			name:   "resize",
+			params: Params{"width-new": "4", "height-new": "3", "filter": "box"},
+		},
+		{
+			name:   "resize",
+			params: Params{"width-new": "7", "height-new": "7", "filter": "bilinear"},
+		},
+		{
+			name:   "resize",
+			params: Params{"width-new": "13", "height-new": "12", "filter": "bilinear"},
+		},
+		{
+			name:   "color-matrix",
+			final:  "../../sample/inverted.png",
+			params: Params{"matrix": "invert"},
+		},
+		{
+			name:  "color-matrix",
+			final: "../../sample/sepia.png",
+		},
+		{
+			name:   "color-matrix",
+			params: Params{"matrix": "1.5,-0.25,0,-16, 0,1,0,0, -16,16,16,4096"},
+		},
+		{
+			name:  "convolve",
+			final: "../../sample/blurred.png",
+		},
+		{
+			name:   "convolve",
+			params: Params{"kernel": "blur5", "border": "mirror"},
+		},
+		{
+			name:   "convolve",
+			params: Params{"kernel": "sharpen", "border": "zero"},
+		},
+		{
+			name:   "convolve",
+			params: Params{"kernel": "edge"},
+		},
+		{
+			name:   "convolve",
+			params: Params{"kernel": "1,1,1,1,1,1,1,1,1", "divisor": "10", "border": "mirror"},
+		},
+		{
+			name:   "lut",
+			final:  "../../sample/gamma.png",
+			params: Params{"gamma": "2.2"},
+		},
+		{
+			name:   "lut",
+			params: Params{"gamma": "0.5"},
+		},
+		{
+			name:   "redact",
+			final:  "../../sample/redacted.png",
+			params: Params{"regions": "4x3+2+2,2x2+7+7", "fill": "pixelate", "block-size": "2"},
+		},
+		{
+			name:   "redact",
+			params: Params{"regions": "1x1+0+0,10x2+0+8", "color": "ff8000"},
+		},
+		{
+			name:   "redact",
+			params: Params{"regions": "3x3+1+1", "fill": "pixelate", "block-size": "4"},
+		},
+	}
+
+	original := loadPixels(t, "../../sample/original.png")
+
+	originalHash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(originalHash, nil, nil)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := Get(tt.name)
+			require.NoError(t, err)
+
+			params := tt.params.WithDefaults(tr)
+
+			// The reference implementation must match the sample image, if any.
+			final, err := tr.Apply(original, params)
+			require.NoError(t, err)
+			if tt.final != "" {
+				require.Equal(t, loadPixels(t, tt.final), final)
+			}
+
+			circuit, err := tr.Circuit(original.Shape(), final.Shape(), params, false)
+			require.NoError(t, err)
+
+			assignment, err := tr.Assignment(original, final, params, provenance)
+			require.NoError(t, err)
+
+			err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			require.NoError(t, err)
+
+			// A tampered final image must not satisfy the circuit.
+			final.Values[0][0][0] ^= 1
+
+			assignment, err = tr.Assignment(original, final, params, provenance)
+			require.NoError(t, err)
+
+			err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAlpha(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+		// keepsAlpha is true if the transformation only changes the colors.
+		keepsAlpha bool
+	}{
+		{name: "crop", params: Params{"width-start-new": "1", "width-new": "2", "height-new": "2"}},
+		{name: "rotate90"},
+		{name: "dihedral", params: Params{"symmetry": "anti-transpose"}},
+		{name: "identity"},
+		{name: "resize", params: Params{"width-new": "2", "height-new": "2"}},
+		{name: "redact", params: Params{"regions": "2x2+1+1", "fill": "pixelate", "block-size": "2"}},
+		{name: "redact", params: Params{"regions": "2x2+1+1", "color": "ff8000"}},
+		{name: "brighten", params: Params{"brightening-factor": "20"}, keepsAlpha: true},
+		{name: "contrast", params: Params{"factor": "1.5"}, keepsAlpha: true},
+		{name: "grayscale", keepsAlpha: true},
+		{name: "color-matrix", keepsAlpha: true},
+		{name: "lut", params: Params{"gamma": "2.2"}, keepsAlpha: true},
+		{name: "convolve", params: Params{"kernel": "edge"}, keepsAlpha: true},
+	}
+
+	// The alpha channel varies from transparent to opaque.
+	original := NewPixels(Shape{Width: 4, Height: 3, Alpha: true})
+	for i := range original.Values {
+		for j := range original.Values[i] {
+			original.Values[i][j] = []uint16{uint16(20 * i), uint16(60 * j), 100, uint16(85 * j)}
+		}
+	}
+
+	originalHash, err := original.Hash()
+	require.NoError(t, err)
+
+	provenance, err := NewProvenance(originalHash, nil, nil)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := Get(tt.name)
+			require.NoError(t, err)
+
+			params := tt.params.WithDefaults(tr)
+			if r, ok := tr.(Resolver); ok {
+				params = r.Resolve(original.Shape(), Shape{}, params)
+			}
+
+			final, err := tr.Apply(original, params)
+			require.NoError(t, err)
+			require.True(t, final.Shape().Alpha)
+
+			if tt.keepsAlpha {
+				for i := range final.Values {
+					for j := range final.Values[i] {
+						require.Equal(t, original.Values[i][j][3], final.Values[i][j][3])
+					}
+				}
+			}
+
+			circuit, err := tr.Circuit(original.Shape(), final.Shape(), params, false)
+			require.NoError(t, err)
+
+			assignment, err := tr.Assignment(original, final, params, provenance)
+			require.NoError(t, err)
+
+			err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			require.NoError(t, err)
+
+			// A tampered alpha channel must not satisfy the circuit.
+			final.Values[0][0][3] ^= 1
+
+			assignment, err = tr.Assignment(original, final, params, provenance)
+			require.NoError(t, err)
+
+			err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			require.Error(t, err)
+
+			// Final images without an alpha channel have a different shape.
+			_, err = tr.Circuit(original.Shape(), Shape{Width: final.Shape().Width, Height: final.Shape().Height}, params, false)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDepth16(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+		// exact is true if the 16-bit result of an 8-bit image is the 8-bit result multiplied by 257.
+		exact bool
+	}{
+		{name: "crop", params: Params{"width-start-new": "1", "width-new": "2", "height-new": "2"}, exact: true},
+		{name: "rotate90", exact: true},
+		{name: "dihedral", params: Params{"symmetry": "anti-transpose"}, exact: true},
+		{name: "identity", exact: true},
+		{name: "resize", params: Params{"width-new": "2", "height-new": "2"}},
+		{name: "redact", params: Params{"regions": "2x2+1+1", "fill": "pixelate", "block-size": "2"}},
+		{name: "redact", params: Params{"regions": "2x2+1+1", "color": "ff8000"}, exact: true},
+		{name: "brighten", params: Params{"brightening-factor": "20"}, exact: true},
+		{name: "contrast", params: Params{"factor": "1.5"}},
+		{name: "grayscale"},
+		{name: "color-matrix"},
+		{name: "lut", params: Params{"gamma": "2.2"}},
+		{name: "convolve", params: Params{"kernel": "edge"}},
+	}
+
+	// The 8-bit image is promoted to 16 bits, then the low bits vary so they are lost at 8 bits.
+	pixels8 := NewPixels(Shape{Width: 4, Height: 3})
+	for i := range pixels8.Values {
+		for j := range pixels8.Values[i] {
+			pixels8.Values[i][j] = []uint16{uint16(20 * i), uint16(60 * j), 255}
+		}
+	}
+
+	original := pixels8.WithDepth16()
+	require.Equal(t, Shape{Width: 4, Height: 3, Depth16: true}, original.Shape())
+	require.Equal(t, []uint16{0, 60 * 257, 65535}, original.Values[0][1])
+
+	precise := NewPixels(original.Shape())
+	for i := range precise.Values {
+		for j := range precise.Values[i] {
+			for k := range precise.Values[i][j] {
+				precise.Values[i][j][k] = original.Values[i][j][k] | uint16(i+3*j+k)
+			}
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := Get(tt.name)
+			require.NoError(t, err)
+
+			params := tt.params.WithDefaults(tr)
+			if r, ok := tr.(Resolver); ok {
+				params = r.Resolve(original.Shape(), Shape{}, params)
+			}
+
+			if tt.exact {
+				final8, err := tr.Apply(pixels8, params)
+				require.NoError(t, err)
+
+				final, err := tr.Apply(original, params)
+				require.NoError(t, err)
+				require.Equal(t, final8.WithDepth16(), final)
+			}
+
+			originalHash, err := precise.Hash()
+			require.NoError(t, err)
+
+			provenance, err := NewProvenance(originalHash, nil, nil)
+			require.NoError(t, err)
+
+			final, err := tr.Apply(precise, params)
+			require.NoError(t, err)
+			require.True(t, final.Shape().Depth16)
+
+			circuit, err := tr.Circuit(precise.Shape(), final.Shape(), params, false)
+			require.NoError(t, err)
+
+			assignment, err := tr.Assignment(precise, final, params, provenance)
+			require.NoError(t, err)
+
+			err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			require.NoError(t, err)
+
+			// The least significant bit of the final image is proven.
+			final.Values[0][0][0] ^= 1
+
+			assignment, err = tr.Assignment(precise, final, params, provenance)
+			require.NoError(t, err)
+
+			err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			require.Error(t, err)
+
+			// Final images with 8-bit channels have a different shape.
+			_, err = tr.Circuit(precise.Shape(), Shape{Width: final.Shape().Width, Height: final.Shape().Height}, params, false)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFromImageDepth16(t *testing.T) {
+	// 16-bit images keep their channel values, 8-bit images are not scaled.
+	img := image.NewRGBA64(image.Rect(0, 0, 2, 1))
+	img.SetRGBA64(0, 0, color.RGBA64{R: 1, G: 258, B: 65535, A: 65535})
+	img.SetRGBA64(1, 0, color.RGBA64{R: 1000, G: 2000, B: 3000, A: 65535})
+
+	pixels := FromImage(img)
+	require.Equal(t, Pixels{Values: [][][]uint16{{{1, 258, 65535}, {1000, 2000, 3000}}}, Depth16: true}, pixels)
+	require.Equal(t, pixels, FromImage(pixels.Image()))
+
+	gray := image.NewGray16(image.Rect(0, 0, 1, 1))
+	gray.SetGray16(0, 0, color.Gray16{Y: 12345})
+	require.Equal(t, Pixels{Values: [][][]uint16{{{12345, 12345, 12345}}}, Depth16: true}, FromImage(gray))
+
+	// Semi-transparent 16-bit pixels are converted at full precision.
+	nrgba := image.NewNRGBA64(image.Rect(0, 0, 1, 1))
+	nrgba.SetNRGBA64(0, 0, color.NRGBA64{R: 4000, G: 3, B: 0, A: 32768})
+	require.Equal(t, Pixels{Values: [][][]uint16{{{4000, 3, 0, 32768}}}, Depth16: true}, FromImage(nrgba))
+
+	rgba := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	rgba.SetRGBA(0, 0, color.RGBA{R: 1, G: 128, B: 255, A: 255})
+	require.Equal(t, Pixels{Values: [][][]uint16{{{1, 128, 255}}}}, FromImage(rgba))
+}
+
+func TestFromImageAlpha(t *testing.T) {
+	// The color channels of premultiplied images are converted, instead of truncated to the premultiplied values.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.SetRGBA(0, 0, color.RGBA{R: 64, G: 32, B: 0, A: 128})
+	img.SetRGBA(1, 0, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+
+	pixels := FromImage(img)
+	require.Equal(t, Shape{Width: 2, Height: 1, Alpha: true}, pixels.Shape())
+	require.Equal(t, Pixels{Values: [][][]uint16{{{127, 63, 0, 128}, {10, 20, 30, 255}}}}, pixels)
+	require.Equal(t, pixels, FromImage(pixels.Image()))
+
+	// The colors of nearly transparent non-premultiplied pixels are kept.
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	nrgba.SetNRGBA(0, 0, color.NRGBA{R: 1, G: 2, B: 255, A: 1})
+	nrgba.SetNRGBA(1, 0, color.NRGBA{R: 200, G: 100, B: 3, A: 2})
+
+	pixels = FromImage(nrgba)
+	require.Equal(t, Pixels{Values: [][][]uint16{{{1, 2, 255, 1}, {200, 100, 3, 2}}}}, pixels)
+	require.Equal(t, pixels, FromImage(pixels.Image()))
+
+	// Opaque images have no alpha channel, unless added for images proven with one.
+	img.SetRGBA(0, 0, color.RGBA{R: 64, G: 32, B: 0, A: 255})
+
+	pixels = FromImage(img)
+	require.Equal(t, Pixels{Values: [][][]uint16{{{64, 32, 0}, {10, 20, 30}}}}, pixels)
+	require.Equal(t, Pixels{Values: [][][]uint16{{{64, 32, 0, 255}, {10, 20, 30, 255}}}}, pixels.WithAlpha())
+}
+
+func TestCircuitShapes(t *testing.T) {
+	original := Shape{Width: 10, Height: 5}
+
+	for _, tr := range All() {
+		t.Run(tr.Name(), func(t *testing.T) {
+			// No transformation results in an image larger than the original with the default parameters.
+			_, err := tr.Circuit(original, Shape{Width: 11, Height: 11}, Params{}.WithDefaults(tr), false)
+			require.Error(t, err)
+		})
+	}
+
+	crop, err := Get("crop")
+	require.NoError(t, err)
+
+	_, err = crop.Circuit(original, Shape{Width: 5, Height: 5}, Params{"width-start-new": "6"}.WithDefaults(crop), false)
+	require.ErrorContains(t, err, "exceeds original image")
+}
+
+func TestRegistry(t *testing.T) {
+	_, err := Get("unknown")
+	require.ErrorContains(t, err, "unknown transformation")
+
+	require.Panics(t, func() {
+		Register(crop{})
+	})
+
+	var names []string
+	for _, tr := range All() {
+		names = append(names, tr.Name())
+	}
+
+	require.IsIncreasing(t, names)
+	require.Subset(t, names, []string{"brighten", "color-matrix", "contrast", "convolve", "crop", "dihedral", "flip-horizontal", "flip-vertical", "grayscale", "identity", "lut", "redact", "reencode", "resize", "rotate180", "rotate270", "rotate90"})
+}
+
+// loadPixels returns the pixel values of the image at the provided path.
+func loadPixels(t *testing.T, path string) Pixels {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	require.NoError(t, err)
+
+	return FromImage(img)
+}